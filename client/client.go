@@ -17,11 +17,16 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
@@ -129,6 +134,78 @@ func (c *Client) GetPipelineStatusForUser(id, forUser string) (*lib.PipelineStat
 	return &status, nil
 }
 
+func (c *Client) GetOperatorsStatus(id string) ([]lib.OperatorStatus, error) {
+	url := fmt.Sprintf("%s/pipeline/%s/operators", c.BaseURL, id)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var operators []lib.OperatorStatus
+	if err := json.NewDecoder(resp.Body).Decode(&operators); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return operators, nil
+}
+
+// GetOperatorLogs returns the container logs of a cloud operator, the caller has to close them.
+// With options.Follow new lines are streamed until ctx is done.
+func (c *Client) GetOperatorLogs(ctx context.Context, id, operatorId string, options lib.LogOptions) (io.ReadCloser, error) {
+	query := neturl.Values{}
+	if options.TailLines != nil {
+		query.Set("tail", strconv.FormatInt(*options.TailLines, 10))
+	}
+	if options.Since != nil {
+		query.Set("since", options.Since.Format(time.RFC3339))
+	}
+	query.Set("previous", strconv.FormatBool(options.Previous))
+	query.Set("follow", strconv.FormatBool(options.Follow))
+	url := fmt.Sprintf("%s/pipeline/%s/operators/%s/logs?%s", c.BaseURL, id, operatorId, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+
+	httpClient := *c.HTTPClient
+	if options.Follow {
+		// following logs lasts until ctx is done, so the client timeout must not apply
+		httpClient.Timeout = 0
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if err := checkResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
 func (c *Client) GetPipelinesStatus(ids []string) ([]lib.PipelineStatus, error) {
 	url := fmt.Sprintf("%s/pipelines", c.BaseURL)
 
@@ -169,6 +246,58 @@ func (c *Client) GetPipelinesStatus(ids []string) ([]lib.PipelineStatus, error)
 	return statuses, nil
 }
 
+// WatchPipelinesStatus streams the status of the user's pipelines, first the current status of every pipeline,
+// afterwards the status of a pipeline whenever it changes. The channel is closed when ctx is done or the stream ends.
+func (c *Client) WatchPipelinesStatus(ctx context.Context) (<-chan lib.PipelineStatus, error) {
+	url := fmt.Sprintf("%s/pipelines/status/stream", c.BaseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	// the stream is open until ctx is done, so the client timeout must not apply
+	httpClient := *c.HTTPClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if err := checkResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	statuses := make(chan lib.PipelineStatus)
+	go func() {
+		defer close(statuses)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var status lib.PipelineStatus
+			if err := json.Unmarshal([]byte(data), &status); err != nil {
+				continue
+			}
+			select {
+			case statuses <- status:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return statuses, nil
+}
+
 func (c *Client) StartPipeline(request lib.PipelineRequest) (*pipeApi.Pipeline, error) {
 	url := fmt.Sprintf("%s/pipeline", c.BaseURL)
 
@@ -208,6 +337,45 @@ func (c *Client) StartPipeline(request lib.PipelineRequest) (*pipeApi.Pipeline,
 	return &pipeline, nil
 }
 
+func (c *Client) ClonePipeline(id string, request lib.PipelineCloneRequest) (*pipeApi.Pipeline, error) {
+	url := fmt.Sprintf("%s/pipeline/%s/clone", c.BaseURL, id)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var pipeline pipeApi.Pipeline
+	if err := json.NewDecoder(resp.Body).Decode(&pipeline); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &pipeline, nil
+}
+
 func (c *Client) UpdatePipeline(request lib.PipelineRequest) (*pipeApi.Pipeline, error) {
 	url := fmt.Sprintf("%s/pipeline", c.BaseURL)
 
@@ -247,6 +415,84 @@ func (c *Client) UpdatePipeline(request lib.PipelineRequest) (*pipeApi.Pipeline,
 	return &pipeline, nil
 }
 
+func (c *Client) PlanStartPipeline(request lib.PipelineRequest) (*lib.PipelinePlan, error) {
+	url := fmt.Sprintf("%s/pipeline?dryRun=true", c.BaseURL)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var plan lib.PipelinePlan
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (c *Client) PlanUpdatePipeline(request lib.PipelineRequest) (*lib.PipelinePlan, error) {
+	url := fmt.Sprintf("%s/pipeline?dryRun=true", c.BaseURL)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var plan lib.PipelinePlan
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &plan, nil
+}
+
 func (c *Client) DeletePipeline(id string) error {
 	url := fmt.Sprintf("%s/pipeline/%s", c.BaseURL, id)
 
@@ -275,6 +521,233 @@ func (c *Client) DeletePipeline(id string) error {
 	return nil
 }
 
+func (c *Client) PausePipeline(id string) error {
+	return c.postPipelineAction(id, "pause")
+}
+
+func (c *Client) ResumePipeline(id string) error {
+	return c.postPipelineAction(id, "resume")
+}
+
+func (c *Client) RestartPipeline(id string) error {
+	return c.postPipelineAction(id, "restart")
+}
+
+func (c *Client) RestartOperator(id, operatorId string) error {
+	return c.postPipelineAction(id, "operators/"+operatorId+"/restart")
+}
+
+func (c *Client) postPipelineAction(id string, action string) error {
+	url := fmt.Sprintf("%s/pipeline/%s/%s", c.BaseURL, id, action)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) ExecuteBatch(request lib.BatchRequest) ([]lib.BatchResult, error) {
+	url := fmt.Sprintf("%s/pipelines/batch", c.BaseURL)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var results []lib.BatchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return results, nil
+}
+
+func (c *Client) StartPipelineAsync(request lib.PipelineRequest) (*lib.Operation, error) {
+	url := fmt.Sprintf("%s/pipeline?async=true", c.BaseURL)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var operation lib.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&operation); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &operation, nil
+}
+
+func (c *Client) UpdatePipelineAsync(request lib.PipelineRequest) (*lib.Operation, error) {
+	url := fmt.Sprintf("%s/pipeline?async=true", c.BaseURL)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var operation lib.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&operation); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &operation, nil
+}
+
+func (c *Client) DeletePipelineAsync(id string) (*lib.Operation, error) {
+	url := fmt.Sprintf("%s/pipeline/%s?async=true", c.BaseURL, id)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var operation lib.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&operation); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &operation, nil
+}
+
+func (c *Client) GetOperation(id string) (*lib.Operation, error) {
+	url := fmt.Sprintf("%s/operations/%s", c.BaseURL, id)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.addHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+
+		}
+	}(resp.Body)
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var operation lib.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&operation); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &operation, nil
+}
+
 func checkResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
@@ -294,6 +767,8 @@ func checkResponse(resp *http.Response) error {
 		return fmt.Errorf("not found (404): %s", bodyString)
 	case http.StatusInternalServerError:
 		return fmt.Errorf("internal server error (500): %s", bodyString)
+	case http.StatusServiceUnavailable:
+		return fmt.Errorf("service unavailable (503): %s", bodyString)
 	default:
 		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bodyString)
 	}
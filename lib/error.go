@@ -36,6 +36,14 @@ type ForbiddenError struct {
 	cError
 }
 
+type UnauthorizedError struct {
+	cError
+}
+
+type UnavailableError struct {
+	cError
+}
+
 func (e *cError) Error() string {
 	return e.err.Error()
 }
@@ -59,3 +67,11 @@ func NewNotFoundError(err error) error {
 func NewForbiddenError(err error) error {
 	return &ForbiddenError{cError{err: err}}
 }
+
+func NewUnauthorizedError(err error) error {
+	return &UnauthorizedError{cError{err: err}}
+}
+
+func NewUnavailableError(err error) error {
+	return &UnavailableError{cError{err: err}}
+}
@@ -17,6 +17,8 @@
 package lib
 
 import (
+	"time"
+
 	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
 )
 
@@ -40,6 +42,89 @@ type PipelineRequest struct {
 	ConsumeAllMessages bool           `json:"consumeAllMessages,omitempty"`
 	Metrics            bool           `json:"metrics,omitempty"`
 	Nodes              []PipelineNode `json:"nodes,omitempty"`
+	UpdateStrategy     string         `json:"updateStrategy,omitempty"`
+	Schedule           *Schedule      `json:"schedule,omitempty"`
+	// Resources apply to all cloud operators of the pipeline, unless their node sets its own.
+	Resources *OperatorResources `json:"resources,omitempty"`
+	// DeploymentMode selects how cloud operators are deployed, the configured default if empty.
+	DeploymentMode string `json:"deploymentMode,omitempty"`
+}
+
+// OperatorResources are the requests and limits of an operator container as Kubernetes quantities, e.g. 100m or 128Mi,
+// and its scaling. A profile sets all requests and limits, values which are given explicitly take precedence over the profile.
+type OperatorResources struct {
+	Profile       string          `json:"profile,omitempty"`
+	CpuRequest    string          `json:"cpuRequest,omitempty"`
+	MemoryRequest string          `json:"memoryRequest,omitempty"`
+	CpuLimit      string          `json:"cpuLimit,omitempty"`
+	MemoryLimit   string          `json:"memoryLimit,omitempty"`
+	Scaling       OperatorScaling `json:"scaling,omitzero"`
+}
+
+// OperatorScaling sets the replicas of an operator. Autoscaling is enabled if MaxReplicas is set, the operator is then
+// scaled between MinReplicas and MaxReplicas to keep the average CPU utilization at TargetCpuUtilization percent
+// of the request or the consumer lag per replica at TargetConsumerLag messages. Operators persisting data are not scaled.
+// Only the Kubernetes driver scales operators.
+type OperatorScaling struct {
+	Replicas             int32 `json:"replicas,omitempty"`
+	MinReplicas          int32 `json:"minReplicas,omitempty"`
+	MaxReplicas          int32 `json:"maxReplicas,omitempty"`
+	TargetCpuUtilization int32 `json:"targetCpuUtilization,omitempty"`
+	TargetConsumerLag    int64 `json:"targetConsumerLag,omitempty"`
+}
+
+// Autoscaled returns true if the replicas of the operator are set by a horizontal pod autoscaler.
+func (s OperatorScaling) Autoscaled() bool {
+	return s.MaxReplicas > 0
+}
+
+// InitialReplicas returns the replicas an operator is started with, at least one.
+func (s OperatorScaling) InitialReplicas() int32 {
+	if s.Autoscaled() {
+		return max(s.MinReplicas, 1)
+	}
+	return max(s.Replicas, 1)
+}
+
+const (
+	ResourceProfileSmall  = "small"
+	ResourceProfileMedium = "medium"
+	ResourceProfileLarge  = "large"
+)
+
+// ResourceProfiles are the predefined resources of operators, small is used if nothing else is configured.
+var ResourceProfiles = map[string]OperatorResources{
+	ResourceProfileSmall:  {Profile: ResourceProfileSmall, CpuRequest: "100m", MemoryRequest: "128Mi", CpuLimit: "500m", MemoryLimit: "512Mi"},
+	ResourceProfileMedium: {Profile: ResourceProfileMedium, CpuRequest: "250m", MemoryRequest: "256Mi", CpuLimit: "750m", MemoryLimit: "1Gi"},
+	ResourceProfileLarge:  {Profile: ResourceProfileLarge, CpuRequest: "500m", MemoryRequest: "512Mi", CpuLimit: "1000m", MemoryLimit: "2Gi"},
+}
+
+// Schedule pauses and resumes a pipeline automatically. Pause and Resume are cron expressions with the fields
+// minute, hour, day of month, month and day of week, evaluated in Timezone, UTC if empty.
+type Schedule struct {
+	Pause    string `json:"pause"`
+	Resume   string `json:"resume"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+const (
+	UpdateStrategyRecreate  = "recreate"
+	UpdateStrategyBlueGreen = "blue-green"
+)
+
+// DeploymentModePipeline runs all cloud operators of a pipeline in a single pod,
+// DeploymentModeOperator runs every cloud operator in a pod of its own. Only the Kubernetes driver supports the operator mode.
+const (
+	DeploymentModePipeline = "pipeline"
+	DeploymentModeOperator = "operator"
+)
+
+// PipelineCloneRequest overrides parts of a registered pipeline when cloning it. Inputs of a node replace
+// the inputs of the operator, configs are merged by name. Everything else is taken from the registered pipeline.
+type PipelineCloneRequest struct {
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Nodes       []PipelineNode `json:"nodes,omitempty"`
 }
 
 type PipelineStatusRequest struct {
@@ -52,6 +137,7 @@ type PipelineNode struct {
 	Config          []NodeConfig          `json:"config,omitempty"`
 	InputSelections []pipe.InputSelection `json:"inputSelections,omitempty"`
 	PersistData     bool                  `json:"persistData,omitempty"`
+	Resources       *OperatorResources    `json:"resources,omitempty"`
 }
 
 type NodeConfig struct {
@@ -79,11 +165,266 @@ type PipelineConfig struct {
 	FlowId         string
 	PipelineId     string
 	UserId         string
+	// Resources of the cloud operators by operator ID, operators without resources get the small profile.
+	Resources map[string]OperatorResources
+	// MaxResources limits the resources the autoscaler may assign, only its limits are used.
+	MaxResources OperatorResources
+	// DeploymentMode is DeploymentModePipeline or DeploymentModeOperator.
+	DeploymentMode string
+}
+
+// OperatorResources returns the resources of the operator, the small profile if none are set.
+func (c PipelineConfig) OperatorResources(operatorId string) OperatorResources {
+	if resources, ok := c.Resources[operatorId]; ok {
+		return resources
+	}
+	return ResourceProfiles[ResourceProfileSmall]
+}
+
+// MaxAllowedResources returns the limits the autoscaler may assign, 1000m and 4000Mi if none are set.
+func (c PipelineConfig) MaxAllowedResources() (cpu, memory string) {
+	cpu, memory = c.MaxResources.CpuLimit, c.MaxResources.MemoryLimit
+	if cpu == "" {
+		cpu = "1000m"
+	}
+	if memory == "" {
+		memory = "4000Mi"
+	}
+	return
 }
 
 type PipelineStatus struct {
-	Name          string `json:"name,omitempty"`
-	Running       bool   `json:"running"`
-	Transitioning bool   `json:"transitioning"`
-	Message       string `json:"message"`
+	Name               string              `json:"name,omitempty"`
+	Running            bool                `json:"running"`
+	Transitioning      bool                `json:"transitioning"`
+	Message            string              `json:"message"`
+	Paused             bool                `json:"paused,omitempty"`
+	Schedule           *Schedule           `json:"schedule,omitempty"`
+	ReadyReplicas      int32               `json:"readyReplicas"`
+	DesiredReplicas    int32               `json:"desiredReplicas"`
+	Conditions         []StatusCondition   `json:"conditions,omitempty"`
+	Events             []StatusEvent       `json:"events,omitempty"`
+	LastTransitionTime *time.Time          `json:"lastTransitionTime,omitempty"`
+	FogOperators       []FogOperatorStatus `json:"fogOperators,omitempty"`
+	Forwarding         []ForwardingStatus  `json:"forwarding,omitempty"`
+}
+
+// StatusCondition is a condition of a deployment of the pipeline, e.g. Available or Progressing.
+type StatusCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// StatusEvent is a recent warning event of the deployments or pods of the pipeline, e.g. FailedScheduling or BackOff.
+type StatusEvent struct {
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Object   string    `json:"object"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// FogOperatorStatus is the state of a local operator of the pipeline as far as it is known to the engine, LastSeen is
+// the last time the fog master requested the operators of the user.
+type FogOperatorStatus struct {
+	OperatorId string     `json:"operatorId"`
+	Name       string     `json:"name,omitempty"`
+	State      string     `json:"state"`
+	Error      string     `json:"error,omitempty"`
+	LastSeen   *time.Time `json:"lastSeen,omitempty"`
+}
+
+const (
+	FogOperatorStateFailed  = "failed"
+	FogOperatorStateUnknown = "unknown"
+)
+
+// ForwardingStatus describes which messages of an operator are forwarded between cloud and fog.
+type ForwardingStatus struct {
+	OperatorId         string `json:"operatorId"`
+	CloudToFog         bool   `json:"cloudToFog"`
+	CloudToFogInstance string `json:"cloudToFogInstance,omitempty"`
+	FogToCloud         bool   `json:"fogToCloud"`
+	FogToCloudOutput   string `json:"fogToCloudOutput,omitempty"`
+}
+
+// OperatorStatus is the state of the container of a cloud operator. While a pipeline runs in more than one pod,
+// the containers of all pods are combined, the operator is only ready if all of them are.
+type OperatorStatus struct {
+	OperatorId            string `json:"operatorId"`
+	Name                  string `json:"name,omitempty"`
+	Image                 string `json:"image,omitempty"`
+	Ready                 bool   `json:"ready"`
+	State                 string `json:"state,omitempty"`
+	Reason                string `json:"reason,omitempty"`
+	RestartCount          int32  `json:"restartCount"`
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+	OOMKilled             bool   `json:"oomKilled"`
+}
+
+const (
+	ContainerStateWaiting    = "waiting"
+	ContainerStateRunning    = "running"
+	ContainerStateTerminated = "terminated"
+)
+
+// LogOptions selects the container logs of an operator. TailLines and Since are optional,
+// Previous selects the logs of the last terminated container and Follow keeps streaming new lines.
+type LogOptions struct {
+	TailLines *int64
+	Since     *time.Time
+	Previous  bool
+	Follow    bool
+}
+
+// PausedPipeline records a paused pipeline, its operators are stopped but its registry entry and data are kept.
+type PausedPipeline struct {
+	PipelineId string    `json:"pipelineId"`
+	UserId     string    `json:"userId"`
+	PausedAt   time.Time `json:"pausedAt"`
+}
+
+type ReconcileResult struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Missing    []string  `json:"missing,omitempty"`
+	Extra      []string  `json:"extra,omitempty"`
+	Recreated  []string  `json:"recreated,omitempty"`
+	Removed    []string  `json:"removed,omitempty"`
+	Skipped    []string  `json:"skipped,omitempty"`
+	Paused     []string  `json:"paused,omitempty"`
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+const (
+	ResourceKindDeployment                      = "Deployment"
+	ResourceKindPersistentVolumeClaim           = "PersistentVolumeClaim"
+	ResourceKindVerticalPodAutoscaler           = "VerticalPodAutoscaler"
+	ResourceKindVerticalPodAutoscalerCheckpoint = "VerticalPodAutoscalerCheckpoint"
+	ResourceKindHorizontalPodAutoscaler         = "HorizontalPodAutoscaler"
+	ResourceKindConfigMap                       = "ConfigMap"
+	ResourceKindKafka2MqttInstance              = "Kafka2MqttInstance"
+)
+
+type PipelineResource struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	PipelineId string `json:"pipelineId"`
+	FlowId     string `json:"flowId,omitempty"`
+	UserId     string `json:"userId,omitempty"`
+}
+
+type GarbageCollectionResult struct {
+	StartedAt  time.Time          `json:"startedAt"`
+	FinishedAt time.Time          `json:"finishedAt"`
+	DryRun     bool               `json:"dryRun"`
+	Orphans    []PipelineResource `json:"orphans,omitempty"`
+	Removed    []PipelineResource `json:"removed,omitempty"`
+	Errors     []string           `json:"errors,omitempty"`
+}
+
+// PipelinePlan describes what starting or updating a pipeline would deploy, without deploying anything.
+type PipelinePlan struct {
+	Pipeline       pipe.Pipeline     `json:"pipeline"`
+	CloudOperators []pipe.Operator   `json:"cloudOperators"`
+	LocalOperators []pipe.Operator   `json:"localOperators"`
+	Resources      []PlannedResource `json:"resources"`
+	Removed        []PlannedResource `json:"removed,omitempty"`
+	Messages       []PlannedMessage  `json:"messages"`
+}
+
+type PlannedResource struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Manifest any    `json:"manifest,omitempty"`
+}
+
+type PlannedMessage struct {
+	Topic   string `json:"topic"`
+	Payload any    `json:"payload"`
+}
+
+const (
+	OperationTypeStart    = "start"
+	OperationTypeUpdate   = "update"
+	OperationTypeDelete   = "delete"
+	OperationTypeRestore  = "restore"
+	OperationTypeRecreate = "recreate"
+	OperationTypePause    = "pause"
+	OperationTypeResume   = "resume"
+	OperationTypeRestart  = "restart"
+)
+
+// States of an operation, pending, registered, driver-created and forwarding-enabled mark the progress of an
+// unfinished operation. An operation is failed if it could not be compensated completely.
+const (
+	OperationStatePending           = "pending"
+	OperationStateRegistered        = "registered"
+	OperationStateDriverCreated     = "driver-created"
+	OperationStateForwardingEnabled = "forwarding-enabled"
+	OperationStateCompleted         = "completed"
+	OperationStateCompensated       = "compensated"
+	OperationStateFailed            = "failed"
+)
+
+const (
+	StepStateRunning            = "running"
+	StepStateDone               = "done"
+	StepStateFailed             = "failed"
+	StepStateInterrupted        = "interrupted"
+	StepStateCompensated        = "compensated"
+	StepStateCompensationFailed = "compensation-failed"
+)
+
+const (
+	BatchActionStart   = "start"
+	BatchActionUpdate  = "update"
+	BatchActionDelete  = "delete"
+	BatchActionPause   = "pause"
+	BatchActionResume  = "resume"
+	BatchActionRestart = "restart"
+)
+
+type BatchRequest struct {
+	Actions []BatchAction `json:"actions"`
+}
+
+// BatchAction is a single operation of a batch request. Start and update take a pipeline request,
+// the other actions the ID of the pipeline.
+type BatchAction struct {
+	Action     string           `json:"action"`
+	PipelineId string           `json:"pipelineId,omitempty"`
+	Request    *PipelineRequest `json:"request,omitempty"`
+}
+
+// BatchResult is the outcome of a batch action, Status is the HTTP status the single request would have returned.
+type BatchResult struct {
+	Action     string         `json:"action"`
+	PipelineId string         `json:"pipelineId,omitempty"`
+	Status     int            `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Pipeline   *pipe.Pipeline `json:"pipeline,omitempty"`
+}
+
+// Operation records the steps of a pipeline lifecycle operation and how it ended.
+type Operation struct {
+	Id         string          `json:"id"`
+	Type       string          `json:"type"`
+	PipelineId string          `json:"pipelineId,omitempty"`
+	UserId     string          `json:"userId"`
+	State      string          `json:"state"`
+	Steps      []OperationStep `json:"steps"`
+	Error      string          `json:"error,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+}
+
+type OperationStep struct {
+	Name  string            `json:"name"`
+	State string            `json:"state"`
+	Data  map[string]string `json:"data,omitempty"`
+	Error string            `json:"error,omitempty"`
 }
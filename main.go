@@ -26,6 +26,7 @@ import (
 	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/api"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
@@ -70,7 +71,10 @@ func main() {
 		return
 	}
 
-	httpHandler, err := api.CreateServer(cfg, pipelineService)
+	ctx, cf := context.WithCancel(context.Background())
+	defer cf()
+
+	httpHandler, err := api.CreateServer(ctx, cfg, pipelineService)
 	if err != nil {
 		util.Logger.Error("error creating http engine", "error", err)
 		ec = 1
@@ -87,8 +91,6 @@ func main() {
 		Addr:    bindAddress,
 		Handler: httpHandler}
 
-	ctx, cf := context.WithCancel(context.Background())
-
 	go func() {
 		util.Wait(ctx, util.Logger, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 		cf()
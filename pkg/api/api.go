@@ -17,6 +17,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"regexp"
@@ -48,7 +49,7 @@ import (
 // @license.name Apache-2.0
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 // @BasePath /
-func CreateServer(cfg *config.Config, pipelineService service.PipelineApiService) (r *gin.Engine, err error) {
+func CreateServer(ctx context.Context, cfg *config.Config, pipelineService service.PipelineApiService) (r *gin.Engine, err error) {
 	var driver service.Driver
 	switch selectedDriver := cfg.Driver; selectedDriver {
 	case "rancher":
@@ -61,18 +62,25 @@ func CreateServer(cfg *config.Config, pipelineService service.PipelineApiService
 		)
 		break
 	default:
-		driver, err = kubernetes_api.NewKubernetes(&cfg.Rancher2, cfg.Debug)
+		var kube *kubernetes_api.Kubernetes
+		kube, err = kubernetes_api.NewKubernetes(&cfg.Rancher2, cfg.VPA, cfg.Debug)
 		if err != nil {
 			util.Logger.Error("Error creating driver", "error", err)
 			return
 		}
+		kube.StartCache(ctx)
+		driver = kube
 	}
 
 	parser := parsing_api.NewParsingApi(cfg.ParserApiEndpoint)
 	permission := permission_api.NewPermissionApi(cfg.PermissionApiEndpoint)
 	kafka2mqtt := kafka2mqtt_api.NewKafka2MqttApi(cfg.Kafka2MqttApiEndpoint, &cfg.Mqtt)
 	deviceManager := devicemanager_api.NewDeviceManagerApi(cfg.DeviceManagerApiEndpoint)
-	flowEngine := service.NewFlowEngine(driver, parser, permission, kafka2mqtt, deviceManager, pipelineService)
+	flowEngine, err := service.NewFlowEngine(ctx, cfg, driver, parser, permission, kafka2mqtt, deviceManager, pipelineService)
+	if err != nil {
+		util.Logger.Error("Error creating flow engine", "error", err)
+		return
+	}
 
 	port := strconv.FormatInt(int64(cfg.ServerPort), 10)
 	util.Logger.Info("Starting api server at port " + port)
@@ -121,6 +129,15 @@ func CreateServer(cfg *config.Config, pipelineService service.PipelineApiService
 	for _, route := range setRoutes {
 		util.Logger.Debug("http route", attributes.MethodKey, route[0], attributes.PathKey, route[1])
 	}
+	admin := prefix.Group("")
+	admin.Use(AdminMiddleware())
+	setRoutes, err = routesAdmin.Set(*flowEngine, admin)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range setRoutes {
+		util.Logger.Debug("http route", attributes.MethodKey, route[0], attributes.PathKey, route[1])
+	}
 	return r, nil
 }
 
@@ -137,6 +154,22 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+func AdminMiddleware() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		if !isAdmin(gc) {
+			util.Logger.Warn("admin route requested by non admin user", "user", gc.GetString(UserIdKey), "path", gc.Request.URL.Path)
+			gc.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		gc.Next()
+	}
+}
+
+func isAdmin(c *gin.Context) bool {
+	roles := strings.Split(c.GetHeader("X-User-Roles"), ", ")
+	return slices.Contains[[]string](roles, "admin")
+}
+
 func getUserId(c *gin.Context) (userId string, err error) {
 	forUser := c.Query("for_user")
 	if forUser != "" {
@@ -147,8 +180,7 @@ func getUserId(c *gin.Context) (userId string, err error) {
 			return "", errors.New("invalid user ID format")
 		}
 
-		roles := strings.Split(c.GetHeader("X-User-Roles"), ", ")
-		if slices.Contains[[]string](roles, "admin") {
+		if isAdmin(c) {
 			util.Logger.Info("user_impersonation",
 				"admin_user", userId,
 				"target_user", forUser,
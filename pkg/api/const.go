@@ -16,16 +16,32 @@
 
 package api
 
+import "time"
+
 const (
 	HeaderRequestID = "X-Request-ID"
 	UserIdKey       = "UserId"
 )
 
 const (
-	HealthCheckPath = "/health-check"
-	PipelineIdPath  = "/pipeline/:id"
-	PipelinesPath   = "/pipelines"
-	PipelinePath    = "/pipeline"
+	HealthCheckPath     = "/health-check"
+	PipelineIdPath      = "/pipeline/:id"
+	PipelinePausePath   = "/pipeline/:id/pause"
+	PipelineResumePath  = "/pipeline/:id/resume"
+	PipelineClonePath   = "/pipeline/:id/clone"
+	PipelineRestartPath = "/pipeline/:id/restart"
+	OperatorsPath       = "/pipeline/:id/operators"
+	OperatorRestartPath = "/pipeline/:id/operators/:operatorId/restart"
+	OperatorLogsPath    = "/pipeline/:id/operators/:operatorId/logs"
+	PipelinesBatchPath  = "/pipelines/batch"
+	StatusStreamPath    = "/pipelines/status/stream"
+	PipelinesPath       = "/pipelines"
+	PipelinePath        = "/pipeline"
+	ReconcilePath       = "/admin/reconcile"
+	GCPath              = "/admin/gc"
+	OperationsPath      = "/admin/operations"
+	OperationPath       = "/admin/operations/:id"
+	UserOperationPath   = "/operations/:id"
 )
 
 const (
@@ -35,4 +51,11 @@ const (
 	MessageParseError     = "failed to parse request"
 	MessageForbidden      = "forbidden"
 	MessageBadInput       = "bad input"
+	MessageUnavailable    = "service unavailable"
+)
+
+const (
+	statusEvent       = "status"
+	errorEvent        = "error"
+	keepAliveInterval = 30 * time.Second
 )
@@ -18,8 +18,12 @@ package api
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/service"
@@ -84,16 +88,68 @@ func postPipelines(flowEngine service.FlowEngine) (string, string, gin.HandlerFu
 	}
 }
 
+// getStatusStream godoc
+// @Summary Stream pipelines status
+// @Description	Streams the status of the user's pipelines as server-sent events named status. The current status of every pipeline is sent first,
+// @Description	afterwards the status of a pipeline whenever it changes. The stream ends if the client does not keep up and has to be opened again.
+// @Description	If the token is no longer accepted, an event named error with the message unauthorized is sent before the stream ends, it has to be opened again with a new token.
+// @Tags Pipeline
+// @Produce text/event-stream
+// @Success	200 {object} lib.PipelineStatus
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipelines/status/stream [get]
+func getStatusStream(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, StatusStreamPath, func(c *gin.Context) {
+		initial, events, closeErr, err := flowEngine.WatchPipelinesStatus(c.Request.Context(), c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not watch pipelines status", "error", err, "method", "GET", "path", StatusStreamPath)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		for _, status := range initial {
+			c.SSEvent(statusEvent, status)
+		}
+		c.Writer.Flush()
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case status, ok := <-events:
+				if !ok {
+					if err := closeErr(); err != nil {
+						util.Logger.Info("closing status stream", "error", err, "path", StatusStreamPath)
+						c.SSEvent(errorEvent, handleError(err).Error())
+					}
+					return false
+				}
+				c.SSEvent(statusEvent, status)
+				return true
+			case <-keepAlive.C:
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			}
+		})
+	}
+}
+
 // postPipeline godoc
 // @Summary Start a pipeline
-// @Description	Starts a pipeline
+// @Description	Starts a pipeline, with dryRun only returns what would be deployed.
+// @Description	A schedule pauses and resumes the pipeline at the times given by its cron expressions.
 // @Tags Pipeline
 // @Produce json
-// @Success	200 {object} pipeApi.Pipeline
+// @Param dryRun query bool false "plan the pipeline without deploying it"
+// @Param async query bool false "start the pipeline in the background and return the operation to poll"
+// @Success	200 {object} pipeApi.Pipeline "the pipeline, with dryRun a lib.PipelinePlan"
+// @Success	202 {object} lib.Operation
 // @Failure 400 {string} MessageBadInput
 // @Failure	401 {string} MessageUnauthorized
 // @Failure	403 {string} MessageForbidden
 // @Failure	500 {string} MessageSomethingWrong
+// @Failure	503 {string} MessageUnavailable
 // @Router /pipeline [post]
 func postPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
 	return http.MethodPost, PipelinePath, func(c *gin.Context) {
@@ -103,8 +159,47 @@ func postPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFun
 			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
 			return
 		}
+		if err := service.ValidateSchedule(request.Schedule); err != nil {
+			util.Logger.Error("invalid schedule", "error", err, "method", "POST", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "POST", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if dryRun {
+			plan, err := flowEngine.PlanStartPipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+			if err != nil {
+				util.Logger.Error("could not plan pipeline",
+					"error", err, "method", "POST", "path", PipelinePath, "flowId", request.FlowId, "user", c.GetString(UserIdKey))
+				_ = c.Error(handleError(err))
+				return
+			}
+			c.JSON(http.StatusOK, plan)
+			return
+		}
+		async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "POST", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if async {
+			operation, err := flowEngine.StartPipelineAsync(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+			if err != nil {
+				util.Logger.Error("could not queue pipeline start",
+					"error", err, "method", "POST", "path", PipelinePath, "flowId", request.FlowId, "user", c.GetString(UserIdKey))
+				_ = c.Error(handleError(err))
+				return
+			}
+			c.JSON(http.StatusAccepted, operation)
+			return
+		}
 		var pipe *pipeApi.Pipeline
-		pipe, err := flowEngine.StartPipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		pipe, err = flowEngine.StartPipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
 		if err != nil {
 			util.Logger.Error("could not start pipeline",
 				"error", err, "method", "POST", "path", PipelinePath, "flowId", request.FlowId, "user", c.GetString(UserIdKey))
@@ -117,14 +212,22 @@ func postPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFun
 
 // putPipeline godoc
 // @Summary Update a pipeline
-// @Description	Updates a pipeline
+// @Description	Updates a pipeline, with dryRun only returns what would be removed and deployed.
+// @Description	The updateStrategy of the request selects between recreate and blue-green, the default is configured.
+// @Description	The schedule of the request replaces the current one, without a schedule the pipeline is no longer paused and resumed automatically.
+// @Description	A paused pipeline has to be resumed before it can be updated.
 // @Tags Pipeline
 // @Produce json
+// @Param dryRun query bool false "plan the update without deploying it"
+// @Param async query bool false "update the pipeline in the background and return the operation to poll"
+// @Success	200 {object} pipeApi.Pipeline "the pipeline, with dryRun a lib.PipelinePlan"
+// @Success	202 {object} lib.Operation
 // @Failure 400 {string} MessageBadInput
 // @Failure	401 {string} MessageUnauthorized
 // @Failure	403 {string} MessageForbidden
 // @Failure	404 {string} MessageNotFound
 // @Failure	500 {string} MessageSomethingWrong
+// @Failure	503 {string} MessageUnavailable
 // @Router /pipeline [put]
 func putPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
 	return http.MethodPut, PipelinePath, func(c *gin.Context) {
@@ -134,8 +237,52 @@ func putPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc
 			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
 			return
 		}
+		if !slices.Contains([]string{"", lib.UpdateStrategyRecreate, lib.UpdateStrategyBlueGreen}, request.UpdateStrategy) {
+			util.Logger.Error("unknown update strategy", "strategy", request.UpdateStrategy, "method", "PUT", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if err := service.ValidateSchedule(request.Schedule); err != nil {
+			util.Logger.Error("invalid schedule", "error", err, "method", "PUT", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "PUT", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if dryRun {
+			plan, err := flowEngine.PlanUpdatePipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+			if err != nil {
+				util.Logger.Error("could not plan pipeline update",
+					"error", err, "method", "PUT", "path", PipelinePath, "pipelineId", request.Id, "user", c.GetString(UserIdKey))
+				_ = c.Error(handleError(err))
+				return
+			}
+			c.JSON(http.StatusOK, plan)
+			return
+		}
+		async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "PUT", "path", PipelinePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if async {
+			operation, err := flowEngine.UpdatePipelineAsync(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+			if err != nil {
+				util.Logger.Error("could not queue pipeline update",
+					"error", err, "method", "PUT", "path", PipelinePath, "pipelineId", request.Id, "user", c.GetString(UserIdKey))
+				_ = c.Error(handleError(err))
+				return
+			}
+			c.JSON(http.StatusAccepted, operation)
+			return
+		}
 		var pipe *pipeApi.Pipeline
-		pipe, err := flowEngine.UpdatePipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		pipe, err = flowEngine.UpdatePipeline(request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
 		if err != nil {
 			util.Logger.Error("could not update pipeline",
 				"error", err, "method", "PUT", "path", PipelinePath, "pipelineId", request.Id, "user", c.GetString(UserIdKey))
@@ -151,17 +298,36 @@ func putPipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc
 // @Description	Delete a single pipeline
 // @Tags Pipeline
 // @Param id path string true "Pipeline ID"
+// @Param async query bool false "delete the pipeline in the background and return the operation to poll"
 // @Success	204
+// @Success	202 {object} lib.Operation
 // @Failure 400 {string} MessageBadInput
 // @Failure	401 {string} MessageUnauthorized
 // @Failure	403 {string} MessageForbidden
 // @Failure	404 {string} MessageNotFound
 // @Failure	500 {string} MessageSomethingWrong
+// @Failure	503 {string} MessageUnavailable
 // @Router /pipeline/{id} [delete]
 func deletePipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
 	return http.MethodDelete, PipelineIdPath, func(c *gin.Context) {
 		id := c.Param("id")
-		err := flowEngine.DeletePipeline(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "DELETE", "path", PipelineIdPath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		if async {
+			operation, err := flowEngine.DeletePipelineAsync(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+			if err != nil {
+				util.Logger.Error("could not queue pipeline deletion", "error", err, "method", "DELETE", "path", PipelineIdPath)
+				_ = c.Error(handleError(err))
+				return
+			}
+			c.JSON(http.StatusAccepted, operation)
+			return
+		}
+		err = flowEngine.DeletePipeline(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
 		if err != nil {
 			util.Logger.Error("could not delete pipeline", "error", err, "method", "DELETE", "path", PipelineIdPath)
 			_ = c.Error(handleError(err))
@@ -171,6 +337,408 @@ func deletePipeline(flowEngine service.FlowEngine) (string, string, gin.HandlerF
 	}
 }
 
+// postPipelinePause godoc
+// @Summary Pause pipeline
+// @Description	Stops the operators and forwarding of a pipeline, but keeps its registry entry, data and consumer groups
+// @Tags Pipeline
+// @Param id path string true "Pipeline ID"
+// @Success	204
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/pause [post]
+func postPipelinePause(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, PipelinePausePath, func(c *gin.Context) {
+		id := c.Param("id")
+		err := flowEngine.PausePipeline(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not pause pipeline", "error", err, "method", "POST", "path", PipelinePausePath, "pipelineId", id)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// postPipelineResume godoc
+// @Summary Resume pipeline
+// @Description	Starts the operators and forwarding of a paused pipeline again
+// @Tags Pipeline
+// @Param id path string true "Pipeline ID"
+// @Success	204
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/resume [post]
+func postPipelineResume(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, PipelineResumePath, func(c *gin.Context) {
+		id := c.Param("id")
+		err := flowEngine.ResumePipeline(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not resume pipeline", "error", err, "method", "POST", "path", PipelineResumePath, "pipelineId", id)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// postPipelineRestart godoc
+// @Summary Restart pipeline
+// @Description	Restarts the operators of a pipeline without changing it, consumers continue at their offsets
+// @Tags Pipeline
+// @Param id path string true "Pipeline ID"
+// @Success	204
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/restart [post]
+func postPipelineRestart(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, PipelineRestartPath, func(c *gin.Context) {
+		id := c.Param("id")
+		err := flowEngine.RestartPipeline(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not restart pipeline", "error", err, "method", "POST", "path", PipelineRestartPath, "pipelineId", id)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// getOperators godoc
+// @Summary Get operators status
+// @Description	Gets the container state of each cloud operator of a pipeline
+// @Tags Pipeline
+// @Produce json
+// @Param id path string true "Pipeline ID"
+// @Success	200 {array} lib.OperatorStatus
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/operators [get]
+func getOperators(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, OperatorsPath, func(c *gin.Context) {
+		id := c.Param("id")
+		operators, err := flowEngine.GetOperatorsStatus(id, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not get operators status", "error", err, "method", "GET", "path", OperatorsPath, "pipelineId", id)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, operators)
+	}
+}
+
+// postOperatorRestart godoc
+// @Summary Restart operator
+// @Description	Restarts a single operator of a pipeline. Unless they are deployed in the operator deployment mode, cloud operators share their pods and cannot be restarted one by one, which is a bad input.
+// @Tags Pipeline
+// @Param id path string true "Pipeline ID"
+// @Param operatorId path string true "Operator ID"
+// @Success	204
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/operators/{operatorId}/restart [post]
+func postOperatorRestart(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, OperatorRestartPath, func(c *gin.Context) {
+		id := c.Param("id")
+		operatorId := c.Param("operatorId")
+		err := flowEngine.RestartOperator(id, operatorId, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not restart operator", "error", err, "method", "POST", "path", OperatorRestartPath, "pipelineId", id, "operatorId", operatorId)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// getOperatorLogs godoc
+// @Summary Get operator logs
+// @Description	Gets the container logs of a cloud operator from the most recently created pod of the pipeline.
+// @Description	since is a duration like 10m or an RFC 3339 timestamp, with follow new lines are streamed until the client disconnects.
+// @Tags Pipeline
+// @Produce plain
+// @Param id path string true "Pipeline ID"
+// @Param operatorId path string true "Operator ID"
+// @Param tail query int false "number of lines from the end of the logs"
+// @Param since query string false "only logs newer than a relative duration or a timestamp"
+// @Param previous query bool false "logs of the previously terminated container"
+// @Param follow query bool false "stream new lines"
+// @Success	200 {string} string
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Failure	503 {string} MessageUnavailable
+// @Router /pipeline/{id}/operators/{operatorId}/logs [get]
+func getOperatorLogs(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, OperatorLogsPath, func(c *gin.Context) {
+		id := c.Param("id")
+		operatorId := c.Param("operatorId")
+		options, err := parseLogOptions(c)
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "GET", "path", OperatorLogsPath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		logs, err := flowEngine.GetOperatorLogs(c.Request.Context(), id, operatorId, options, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not get operator logs", "error", err, "method", "GET", "path", OperatorLogsPath, "pipelineId", id, "operatorId", operatorId)
+			_ = c.Error(handleError(err))
+			return
+		}
+		defer logs.Close()
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		buf := make([]byte, 32*1024)
+		c.Stream(func(w io.Writer) bool {
+			n, err := logs.Read(buf)
+			if n > 0 {
+				if _, wErr := w.Write(buf[:n]); wErr != nil {
+					return false
+				}
+			}
+			return err == nil
+		})
+	}
+}
+
+// postPipelineClone godoc
+// @Summary Clone pipeline
+// @Description	Starts a copy of a pipeline, the request overrides its name, description and the inputs and configs of its nodes.
+// @Description	Inputs of local operators have to be given.
+// @Tags Pipeline
+// @Accept json
+// @Produce json
+// @Param id path string true "Pipeline ID"
+// @Param request body lib.PipelineCloneRequest true "Overrides of the copy"
+// @Success	200 {object} pipeApi.Pipeline
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipeline/{id}/clone [post]
+func postPipelineClone(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, PipelineClonePath, func(c *gin.Context) {
+		id := c.Param("id")
+		var request lib.PipelineCloneRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "POST", "path", PipelineClonePath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		pipe, err := flowEngine.ClonePipeline(id, request, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		if err != nil {
+			util.Logger.Error("could not clone pipeline", "error", err, "method", "POST", "path", PipelineClonePath, "pipelineId", id)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, pipe)
+	}
+}
+
+// postPipelinesBatch godoc
+// @Summary Execute pipeline actions in batch
+// @Description	Starts, updates, deletes, pauses, resumes or restarts multiple pipelines, a limited number of them at the same time.
+// @Description	Returns a result per action in the order of the actions, with the status code the single request would have returned.
+// @Tags Pipeline
+// @Accept json
+// @Produce json
+// @Param request body lib.BatchRequest true "Batch request"
+// @Success	200 {array} lib.BatchResult
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /pipelines/batch [post]
+func postPipelinesBatch(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, PipelinesBatchPath, func(c *gin.Context) {
+		var request lib.BatchRequest
+		if err := c.ShouldBindJSON(&request); err != nil || len(request.Actions) == 0 {
+			util.Logger.Error(MessageParseError, "error", err, "method", "POST", "path", PipelinesBatchPath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		pipelines, errs := flowEngine.ExecuteBatch(request.Actions, c.GetString(UserIdKey), c.GetHeader("Authorization"))
+		results := make([]lib.BatchResult, len(request.Actions))
+		for i, action := range request.Actions {
+			results[i] = lib.BatchResult{Action: action.Action, PipelineId: action.PipelineId, Status: http.StatusOK, Pipeline: pipelines[i]}
+			if pipelines[i] != nil {
+				results[i].PipelineId = pipelines[i].Id
+			}
+			if errs[i] != nil {
+				util.Logger.Error("could not execute batch action", "error", errs[i], "method", "POST", "path", PipelinesBatchPath, "action", action.Action, "pipelineId", action.PipelineId)
+				err := handleError(errs[i])
+				results[i].Status = util.GetStatusCode(err)
+				results[i].Error = err.Error()
+			}
+		}
+		c.JSON(http.StatusOK, results)
+	}
+}
+
+// getUserOperation godoc
+// @Summary Get operation
+// @Description	Gets the progress, per-step status and final error of a pipeline operation started by the user
+// @Tags Pipeline
+// @Produce json
+// @Param id path string true "Operation ID"
+// @Success	200 {object} lib.Operation
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /operations/{id} [get]
+func getUserOperation(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, UserOperationPath, func(c *gin.Context) {
+		operation, err := flowEngine.GetUserOperation(c.Param("id"), c.GetString(UserIdKey))
+		if err != nil {
+			util.Logger.Error("could not get operation", "error", err, "method", "GET", "path", UserOperationPath)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, operation)
+	}
+}
+
+// getReconcile godoc
+// @Summary Get last reconciliation result
+// @Description	Gets the result of the last reconciliation between the pipeline registry and the deployments, requires admin role
+// @Tags Admin
+// @Produce json
+// @Success	200 {object} lib.ReconcileResult
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/reconcile [get]
+func getReconcile(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, ReconcilePath, func(c *gin.Context) {
+		result, err := flowEngine.GetLastReconcileResult()
+		if err != nil {
+			util.Logger.Error("could not get reconcile result", "error", err, "method", "GET", "path", ReconcilePath)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// postReconcile godoc
+// @Summary Run reconciliation
+// @Description	Reconciles the pipeline registry with the deployments immediately, requires admin role
+// @Tags Admin
+// @Produce json
+// @Success	200 {object} lib.ReconcileResult
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/reconcile [post]
+func postReconcile(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, ReconcilePath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, flowEngine.Reconcile())
+	}
+}
+
+// getGC godoc
+// @Summary Get last garbage collection result
+// @Description	Gets the result of the last garbage collection of orphaned pipeline resources, requires admin role
+// @Tags Admin
+// @Produce json
+// @Success	200 {object} lib.GarbageCollectionResult
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/gc [get]
+func getGC(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, GCPath, func(c *gin.Context) {
+		result, err := flowEngine.GetLastGarbageCollectionResult()
+		if err != nil {
+			util.Logger.Error("could not get garbage collection result", "error", err, "method", "GET", "path", GCPath)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// postGC godoc
+// @Summary Run garbage collection
+// @Description	Lists resources of pipelines unknown to the pipeline registry and deletes them if dryRun is set to false, requires admin role
+// @Tags Admin
+// @Produce json
+// @Param dryRun query bool false "only report orphaned resources, defaults to true"
+// @Success	200 {object} lib.GarbageCollectionResult
+// @Failure 400 {string} MessageBadInput
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/gc [post]
+func postGC(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodPost, GCPath, func(c *gin.Context) {
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "true"))
+		if err != nil {
+			util.Logger.Error(MessageParseError, "error", err, "method", "POST", "path", GCPath)
+			_ = c.Error(lib.NewInputError(errors.New(MessageBadInput)))
+			return
+		}
+		c.JSON(http.StatusOK, flowEngine.CollectGarbage(dryRun))
+	}
+}
+
+// getOperations godoc
+// @Summary Get pipeline operations
+// @Description	Lists recorded start, update and restore operations with their steps, newest first, requires admin role
+// @Tags Admin
+// @Produce json
+// @Param pipelineId query string false "only operations of this pipeline"
+// @Success	200 {array} lib.Operation
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/operations [get]
+func getOperations(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, OperationsPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, flowEngine.GetOperations(c.Query("pipelineId")))
+	}
+}
+
+// getOperation godoc
+// @Summary Get pipeline operation
+// @Description	Gets a single recorded pipeline operation with its steps, requires admin role
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Operation ID"
+// @Success	200 {object} lib.Operation
+// @Failure	401 {string} MessageUnauthorized
+// @Failure	403 {string} MessageForbidden
+// @Failure	404 {string} MessageNotFound
+// @Failure	500 {string} MessageSomethingWrong
+// @Router /admin/operations/{id} [get]
+func getOperation(flowEngine service.FlowEngine) (string, string, gin.HandlerFunc) {
+	return http.MethodGet, OperationPath, func(c *gin.Context) {
+		operation, err := flowEngine.GetOperation(c.Param("id"))
+		if err != nil {
+			util.Logger.Error("could not get operation", "error", err, "method", "GET", "path", OperationPath)
+			_ = c.Error(handleError(err))
+			return
+		}
+		c.JSON(http.StatusOK, operation)
+	}
+}
+
 func getHealthCheckH(_ service.FlowEngine) (string, string, gin.HandlerFunc) {
 	return http.MethodGet, HealthCheckPath, func(c *gin.Context) {
 		c.Status(http.StatusOK)
@@ -18,8 +18,11 @@ package api
 
 import (
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/gin-gonic/gin"
 )
 
 func handleError(err error) error {
@@ -28,11 +31,45 @@ func handleError(err error) error {
 	}
 
 	switch {
+	case errors.As(err, new(*lib.InputError)):
+		return lib.NewInputError(errors.New(MessageBadInput))
 	case errors.As(err, new(*lib.NotFoundError)):
 		return lib.NewNotFoundError(errors.New(MessageNotFound))
 	case errors.As(err, new(*lib.ForbiddenError)):
 		return lib.NewForbiddenError(errors.New(MessageForbidden))
+	case errors.As(err, new(*lib.UnauthorizedError)):
+		return lib.NewUnauthorizedError(errors.New(MessageUnauthorized))
+	case errors.As(err, new(*lib.UnavailableError)):
+		return lib.NewUnavailableError(errors.New(MessageUnavailable))
 	default:
 		return lib.NewInternalError(errors.New(MessageSomethingWrong))
 	}
 }
+
+// parseLogOptions reads the log options from the query, since is either a duration or an RFC 3339 timestamp.
+func parseLogOptions(c *gin.Context) (options lib.LogOptions, err error) {
+	if tail := c.Query("tail"); tail != "" {
+		lines, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil {
+			return options, err
+		}
+		if lines < 0 {
+			return options, errors.New("tail must not be negative")
+		}
+		options.TailLines = &lines
+	}
+	if since := c.Query("since"); since != "" {
+		var t time.Time
+		if d, err := time.ParseDuration(since); err == nil {
+			t = time.Now().Add(-d)
+		} else if t, err = time.Parse(time.RFC3339, since); err != nil {
+			return options, err
+		}
+		options.Since = &t
+	}
+	if options.Previous, err = strconv.ParseBool(c.DefaultQuery("previous", "false")); err != nil {
+		return
+	}
+	options.Follow, err = strconv.ParseBool(c.DefaultQuery("follow", "false"))
+	return
+}
@@ -30,6 +30,25 @@ var routesAuth = gin_mw.Routes[service.FlowEngine]{
 	getPipeline,
 	postPipeline,
 	postPipelines,
+	getStatusStream,
 	putPipeline,
 	deletePipeline,
+	postPipelinePause,
+	postPipelineResume,
+	postPipelineClone,
+	postPipelineRestart,
+	getOperators,
+	postOperatorRestart,
+	getOperatorLogs,
+	postPipelinesBatch,
+	getUserOperation,
+}
+
+var routesAdmin = gin_mw.Routes[service.FlowEngine]{
+	getReconcile,
+	postReconcile,
+	getGC,
+	postGC,
+	getOperations,
+	getOperation,
 }
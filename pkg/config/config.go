@@ -17,6 +17,8 @@
 package config
 
 import (
+	"time"
+
 	sb_config_hdl "github.com/SENERGY-Platform/go-service-base/config-hdl"
 )
 
@@ -31,30 +33,88 @@ type LoggerConfig struct {
 }
 
 type Rancher2Config struct {
-	Endpoint       string  `json:"endpoint" env_var:"RANCHER2_ENDPOINT"`
-	AccessKey      string  `json:"access_key" env_var:"RANCHER2_ACCESS_KEY"`
-	SecretKey      string  `json:"secret_key" env_var:"RANCHER2_SECRET_KEY"`
-	StackId        string  `json:"stack_id" env_var:"RANCHER2_STACK_ID"`
-	ProjectId      string  `json:"project_id" env_var:"RANCHER2_PROJECT_ID"`
-	NamespaceId    string  `json:"namespace_id" env_var:"RANCHER2_NAMESPACE_ID"`
-	StorageDriver  *string `json:"storage_driver" env_var:"RANCHER2_STORAGE_DRIVER"`
-	Zookeeper      string  `json:"zookeeper" env_var:"ZOOKEEPER"`
-	KafkaBootstrap string  `json:"kafka_bootstrap" env_var:"KAFKA_BOOTSTRAP"`
+	Endpoint          string  `json:"endpoint" env_var:"RANCHER2_ENDPOINT"`
+	AccessKey         string  `json:"access_key" env_var:"RANCHER2_ACCESS_KEY"`
+	SecretKey         string  `json:"secret_key" env_var:"RANCHER2_SECRET_KEY"`
+	StackId           string  `json:"stack_id" env_var:"RANCHER2_STACK_ID"`
+	ProjectId         string  `json:"project_id" env_var:"RANCHER2_PROJECT_ID"`
+	NamespaceId       string  `json:"namespace_id" env_var:"RANCHER2_NAMESPACE_ID"`
+	StorageDriver     *string `json:"storage_driver" env_var:"RANCHER2_STORAGE_DRIVER"`
+	Zookeeper         string  `json:"zookeeper" env_var:"ZOOKEEPER"`
+	KafkaBootstrap    string  `json:"kafka_bootstrap" env_var:"KAFKA_BOOTSTRAP"`
+	ConsumerLagMetric string  `json:"consumer_lag_metric" env_var:"CONSUMER_LAG_METRIC"`
+}
+
+type ReconcileConfig struct {
+	Interval    time.Duration `json:"interval" env_var:"RECONCILE_INTERVAL"`
+	RemoveExtra bool          `json:"remove_extra" env_var:"RECONCILE_REMOVE_EXTRA"`
+}
+
+type GarbageCollectionConfig struct {
+	Interval time.Duration `json:"interval" env_var:"GC_INTERVAL"`
+	DryRun   bool          `json:"dry_run" env_var:"GC_DRY_RUN"`
+}
+
+type OperationsConfig struct {
+	Workers          int `json:"workers" env_var:"OPERATION_WORKERS"`
+	QueueSize        int `json:"queue_size" env_var:"OPERATION_QUEUE_SIZE"`
+	BatchConcurrency int `json:"batch_concurrency" env_var:"OPERATION_BATCH_CONCURRENCY"`
+}
+
+type UpdateConfig struct {
+	Strategy     string        `json:"strategy" env_var:"UPDATE_STRATEGY"`
+	Timeout      time.Duration `json:"timeout" env_var:"UPDATE_TIMEOUT"`
+	PollInterval time.Duration `json:"poll_interval" env_var:"UPDATE_POLL_INTERVAL"`
+}
+
+// ResourcesConfig sets the resource profile of operators which neither have one set nor a cost in the operator catalog,
+// and the maximum resources and replicas an operator may be given. The maxima also limit the vertical pod autoscaler.
+type ResourcesConfig struct {
+	DefaultProfile string `json:"default_profile" env_var:"RESOURCES_DEFAULT_PROFILE"`
+	MaxCpu         string `json:"max_cpu" env_var:"RESOURCES_MAX_CPU"`
+	MaxMemory      string `json:"max_memory" env_var:"RESOURCES_MAX_MEMORY"`
+	MaxReplicas    int    `json:"max_replicas" env_var:"RESOURCES_MAX_REPLICAS"`
+}
+
+// VPAConfig sets up the vertical pod autoscaler of operator deployments in the Kubernetes driver. UpdateMode is one of
+// Off, Initial, Recreate or InPlaceOrRecreate. Profiles bound the resources the autoscaler may assign to the operators
+// of a resource profile, without a maximum the one of ResourcesConfig is used. The autoscaler is disabled if the
+// cluster does not provide its custom resource definitions.
+type VPAConfig struct {
+	Enabled    bool                        `json:"enabled" env_var:"VPA_ENABLED"`
+	UpdateMode string                      `json:"update_mode" env_var:"VPA_UPDATE_MODE"`
+	Profiles   map[string]VPAProfileConfig `json:"profiles" env_var:"VPA_PROFILES"`
+}
+
+type VPAProfileConfig struct {
+	MinCpu    string `json:"min_cpu"`
+	MinMemory string `json:"min_memory"`
+	MaxCpu    string `json:"max_cpu"`
+	MaxMemory string `json:"max_memory"`
 }
 
 type Config struct {
-	Mqtt                     MqttConfig     `json:"mqtt" env_var:"MQTT_CONFIG"`
-	Logger                   LoggerConfig   `json:"logger" env_var:"LOGGER_CONFIG"`
-	URLPrefix                string         `json:"url_prefix" env_var:"URL_PREFIX"`
-	ServerPort               int            `json:"server_port" env_var:"SERVER_PORT"`
-	Driver                   string         `json:"driver" env_var:"DRIVER"`
-	Rancher2                 Rancher2Config `json:"rancher2" env_var:"RANCHER2_CONFIG"`
-	Debug                    bool           `json:"debug" env_var:"DEBUG"`
-	ParserApiEndpoint        string         `json:"parser_api_endpoint" env_var:"PARSER_API_ENDPOINT"`
-	PermissionApiEndpoint    string         `json:"permission_api_endpoint" env_var:"PERMISSION_API_ENDPOINT"`
-	Kafka2MqttApiEndpoint    string         `json:"kafka2mqtt_api_endpoint" env_var:"KAFKA2MQTT_API_ENDPOINT"`
-	DeviceManagerApiEndpoint string         `json:"device_manager_api_endpoint" env_var:"DEVICE_MANAGER_API_ENDPOINT"`
-	PipelineApiEndpoint      string         `json:"pipeline_api_endpoint" env_var:"PIPELINE_API_ENDPOINT"`
+	Mqtt                     MqttConfig              `json:"mqtt" env_var:"MQTT_CONFIG"`
+	Logger                   LoggerConfig            `json:"logger" env_var:"LOGGER_CONFIG"`
+	URLPrefix                string                  `json:"url_prefix" env_var:"URL_PREFIX"`
+	ServerPort               int                     `json:"server_port" env_var:"SERVER_PORT"`
+	Driver                   string                  `json:"driver" env_var:"DRIVER"`
+	Rancher2                 Rancher2Config          `json:"rancher2" env_var:"RANCHER2_CONFIG"`
+	Debug                    bool                    `json:"debug" env_var:"DEBUG"`
+	ParserApiEndpoint        string                  `json:"parser_api_endpoint" env_var:"PARSER_API_ENDPOINT"`
+	PermissionApiEndpoint    string                  `json:"permission_api_endpoint" env_var:"PERMISSION_API_ENDPOINT"`
+	Kafka2MqttApiEndpoint    string                  `json:"kafka2mqtt_api_endpoint" env_var:"KAFKA2MQTT_API_ENDPOINT"`
+	DeviceManagerApiEndpoint string                  `json:"device_manager_api_endpoint" env_var:"DEVICE_MANAGER_API_ENDPOINT"`
+	PipelineApiEndpoint      string                  `json:"pipeline_api_endpoint" env_var:"PIPELINE_API_ENDPOINT"`
+	Reconcile                ReconcileConfig         `json:"reconcile" env_var:"RECONCILE_CONFIG"`
+	GarbageCollection        GarbageCollectionConfig `json:"garbage_collection" env_var:"GC_CONFIG"`
+	DataDir                  string                  `json:"data_dir" env_var:"DATA_DIR"`
+	RequireDataVolume        bool                    `json:"require_data_volume" env_var:"REQUIRE_DATA_VOLUME"`
+	Operations               OperationsConfig        `json:"operations" env_var:"OPERATIONS_CONFIG"`
+	Update                   UpdateConfig            `json:"update" env_var:"UPDATE_CONFIG"`
+	Resources                ResourcesConfig         `json:"resources" env_var:"RESOURCES_CONFIG"`
+	DeploymentMode           string                  `json:"deployment_mode" env_var:"DEPLOYMENT_MODE"`
+	VPA                      VPAConfig               `json:"vpa" env_var:"VPA_CONFIG"`
 }
 
 func New(path string) (*Config, error) {
@@ -68,9 +128,40 @@ func New(path string) (*Config, error) {
 		ServerPort: 8000,
 		Debug:      false,
 		Rancher2: Rancher2Config{
-			ProjectId:      "_:_",
-			Zookeeper:      "zookeeper.kafka:2181",
-			KafkaBootstrap: "kafka.kafka:9092",
+			ProjectId:         "_:_",
+			Zookeeper:         "zookeeper.kafka:2181",
+			KafkaBootstrap:    "kafka.kafka:9092",
+			ConsumerLagMetric: "kafka_consumergroup_lag",
+		},
+		Reconcile: ReconcileConfig{
+			Interval:    5 * time.Minute,
+			RemoveExtra: false,
+		},
+		GarbageCollection: GarbageCollectionConfig{
+			Interval: 0,
+			DryRun:   true,
+		},
+		DataDir: "./data",
+		Operations: OperationsConfig{
+			Workers:          4,
+			QueueSize:        100,
+			BatchConcurrency: 4,
+		},
+		Update: UpdateConfig{
+			Strategy:     "recreate",
+			Timeout:      5 * time.Minute,
+			PollInterval: 5 * time.Second,
+		},
+		Resources: ResourcesConfig{
+			DefaultProfile: "small",
+			MaxCpu:         "1000m",
+			MaxMemory:      "4000Mi",
+			MaxReplicas:    10,
+		},
+		DeploymentMode: "pipeline",
+		VPA: VPAConfig{
+			Enabled:    true,
+			UpdateMode: "Recreate",
 		},
 	}
 	err := sb_config_hdl.Load(&cfg, nil, envTypeParser, nil, path)
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "0 8 * * 1-5", "*/15 8-18/2 1,15 * 0,7", "30 23 31 12 *"} {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("unexpected error for %q: %v", expr, err)
+		}
+	}
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Friday
+	now := time.Date(2026, 3, 27, 18, 30, 0, 0, loc)
+	tests := []struct {
+		expr string
+		next time.Time
+		prev time.Time
+	}{
+		{"0 8 * * 1-5", time.Date(2026, 3, 30, 8, 0, 0, 0, loc), time.Date(2026, 3, 27, 8, 0, 0, 0, loc)},
+		{"*/20 * * * *", time.Date(2026, 3, 27, 18, 40, 0, 0, loc), time.Date(2026, 3, 27, 18, 20, 0, 0, loc)},
+		{"30 18 * * *", time.Date(2026, 3, 28, 18, 30, 0, 0, loc), now},
+		{"0 0 1 * 0", time.Date(2026, 3, 29, 0, 0, 0, 0, loc), time.Date(2026, 3, 22, 0, 0, 0, 0, loc)},
+		{"30 2 * * *", time.Date(2026, 3, 28, 2, 30, 0, 0, loc), time.Date(2026, 3, 27, 2, 30, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := s.Next(now)
+		if err != nil || !next.Equal(tt.next) {
+			t.Errorf("%q: expected next %v, got %v, error %v", tt.expr, tt.next, next, err)
+		}
+		prev, err := s.Prev(now)
+		if err != nil || !prev.Equal(tt.prev) {
+			t.Errorf("%q: expected prev %v, got %v, error %v", tt.expr, tt.prev, prev, err)
+		}
+	}
+	// 02:30 is skipped by the switch to summer time on March 29th
+	s, _ := Parse("30 2 * * *")
+	if next, _ := s.Next(time.Date(2026, 3, 28, 3, 0, 0, 0, loc)); !next.Equal(time.Date(2026, 3, 30, 2, 30, 0, 0, loc)) {
+		t.Errorf("expected activation to be skipped on March 29th, got %v", next)
+	}
+	s, _ = Parse("0 0 30 2 *")
+	if _, err = s.Next(now); err == nil {
+		t.Error("expected no activation on February 30th")
+	}
+}
@@ -23,6 +23,11 @@ func NewKafka2MqttApi(url string, mqttCfg *config.MqttConfig) *Kafka2MqttApi {
 }
 
 func (api *Kafka2MqttApi) StartOperatorInstance(operatorName, operatorID string, pipelineId string, userID, token string) (_ Instance, err error) {
+	return api.startInstance(api.GetOperatorInstanceConfig(operatorName, operatorID, pipelineId, userID), userID, token)
+}
+
+// GetOperatorInstanceConfig returns the instance StartOperatorInstance would create for the operator.
+func (api *Kafka2MqttApi) GetOperatorInstanceConfig(operatorName, operatorID string, pipelineId string, userID string) Instance {
 	mqttBaseTopic := downstreamLib.GetDownstreamOperatorCloudPubTopicPrefix(userID)
 	mqttTopic := operatorLib.GenerateFogOperatorTopic(operatorName, operatorID, pipelineId)
 	kafkaTopic := operatorLib.GenerateCloudOperatorTopic(operatorName)
@@ -30,7 +35,7 @@ func (api *Kafka2MqttApi) StartOperatorInstance(operatorName, operatorID string,
 	brokerAddress := api.mqttCfg.BrokerAddress
 	username := api.mqttCfg.BrokerUser
 	password := api.mqttCfg.BrokerPassword
-	instanceConfig := Instance{
+	return Instance{
 		Topic:      kafkaTopic,
 		FilterType: "operatorId",
 		Filter:     pipelineId + ":" + operatorID,
@@ -46,7 +51,6 @@ func (api *Kafka2MqttApi) StartOperatorInstance(operatorName, operatorID string,
 		CustomMqttUser:      &username,
 		CustomMqttPassword:  &password,
 	}
-	return api.startInstance(instanceConfig, userID, token)
 }
 
 func (api *Kafka2MqttApi) startInstance(instanceConfig Instance, userID, authorization string) (createdInstance Instance, err error) {
@@ -5,19 +5,23 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
 	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
 	appsv1 "k8s.io/api/apps/v1"
-	autoscaling "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	apiv1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	autoscaler "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/clientset/versioned"
 	"k8s.io/client-go/kubernetes"
@@ -31,9 +35,17 @@ type Kubernetes struct {
 	clientset           *kubernetes.Clientset
 	autoscalerClientset *autoscaler.Clientset
 	r2cfg               *config.Rancher2Config
+	vpaCfg              config.VPAConfig
+	vpaAvailable        bool
+	deployments         *deploymentCache
 }
 
-func NewKubernetes(r2cfg *config.Rancher2Config, debug bool) (kube *Kubernetes, err error) {
+// NewKubernetes creates the driver, the vertical pod autoscaler is only used if the cluster provides it.
+func NewKubernetes(r2cfg *config.Rancher2Config, vpaCfg config.VPAConfig, debug bool) (kube *Kubernetes, err error) {
+	if err = ValidateVPAConfig(vpaCfg); err != nil {
+		return nil, err
+	}
+
 	var restConfig *rest.Config
 
 	if debug {
@@ -63,149 +75,307 @@ func NewKubernetes(r2cfg *config.Rancher2Config, debug bool) (kube *Kubernetes,
 
 	// create the clientset
 	clientset, err := kubernetes.NewForConfig(restConfig)
-	autoscalerClientSet, err := autoscaler.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
 	}
 	util.Logger.Debug("loaded clientset")
 
+	var autoscalerClientSet *autoscaler.Clientset
+	vpa, err := detectVPA(clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	if vpa {
+		autoscalerClientSet, err = autoscaler.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+	} else if vpaCfg.Enabled {
+		util.Logger.Warn("vertical pod autoscaler not available in cluster, operators are deployed without it")
+	}
+
 	pods, err := clientset.CoreV1().Pods(r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 	util.Logger.Debug("succesfully tested connection", "pods", len(pods.Items))
 
-	return &Kubernetes{clientset: clientset, autoscalerClientset: autoscalerClientSet, r2cfg: r2cfg}, nil
+	return &Kubernetes{
+		clientset:           clientset,
+		autoscalerClientset: autoscalerClientSet,
+		r2cfg:               r2cfg,
+		vpaCfg:              vpaCfg,
+		vpaAvailable:        vpa,
+		deployments:         newDeploymentCache(clientset, r2cfg.NamespaceId),
+	}, nil
+}
+
+// pipelineResources holds the objects created for the cloud operators of a pipeline, the root object owning
+// all others and a deployment and autoscalers for all operators or one of each per operator.
+// The vertical autoscaler of a deployment is nil if it is disabled, the horizontal one if it is not autoscaled.
+type pipelineResources struct {
+	root        *apiv1.ConfigMap
+	deployments []*appsv1.Deployment
+	vpas        []*v1.VerticalPodAutoscaler
+	hpas        []*autoscalingv2.HorizontalPodAutoscaler
+	pvcs        []*apiv1.PersistentVolumeClaim
 }
 
 func (k *Kubernetes) CreateOperators(pipelineId string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) (err error) {
-	var containers []apiv1.Container
-	var volumes []apiv1.Volume
-	metricsBasePort := 8080
+	return k.createOperators(pipelineId, "", inputs, pipeConfig)
+}
+
+// createOperators applies the objects of the cloud operators of a pipeline. They are applied server-side,
+// so that calling it again after a partial failure creates the missing objects and updates the existing ones.
+// The root object is applied first, as all other objects are owned by it.
+func (k *Kubernetes) createOperators(pipelineId, version string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) (err error) {
+	resources := k.makePipelineResources(pipelineId, version, inputs, pipeConfig)
 	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
 	pvcClient := k.clientset.CoreV1().PersistentVolumeClaims(k.r2cfg.NamespaceId)
+	horizontalAutoscalerClient := k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.r2cfg.NamespaceId)
 
-	for i, operator := range inputs {
-		var ports []apiv1.ContainerPort
-		var volumeMounts []apiv1.VolumeMount
-		operatorRequestConfig, _ := json.Marshal(lib.OperatorRequestConfig{Config: operator.Config, InputTopics: operator.InputTopics})
-		envs := []apiv1.EnvVar{
-			{
-				Name:  "ZK_QUORUM",
-				Value: k.r2cfg.Zookeeper,
-			},
-			{
-				Name:  "CONFIG_BOOTSTRAP_SERVERS",
-				Value: k.r2cfg.KafkaBootstrap,
-			},
-			{
-				Name:  "CONFIG_APPLICATION_ID",
-				Value: "analytics-" + operator.ApplicationId.String(),
-			},
-			{
-				Name:  "PIPELINE_ID",
-				Value: pipelineId,
-			},
-			{
-				Name:  "OPERATOR_ID",
-				Value: operator.Id,
-			},
-			{
-				Name:  "WINDOW_TIME",
-				Value: strconv.Itoa(pipeConfig.WindowTime),
-			},
-			{
-				Name:  "JOIN_STRATEGY",
-				Value: pipeConfig.MergeStrategy,
-			},
-			{
-				Name:  "CONFIG",
-				Value: string(operatorRequestConfig),
-			},
-			{
-				Name:  "DEVICE_ID_PATH",
-				Value: "device_id",
-			},
-			{
-				Name:  "CONSUMER_AUTO_OFFSET_RESET_CONFIG",
-				Value: pipeConfig.ConsumerOffset,
-			},
-			{
-				Name:  "USER_ID",
-				Value: pipeConfig.UserId,
-			},
+	root, err := apply(k.clientset.CoreV1().ConfigMaps(k.r2cfg.NamespaceId), resources.root.Name, resources.root)
+	if err != nil {
+		return fmt.Errorf("applying root %s: %w", resources.root.Name, err)
+	}
+	resources.setOwner(root)
+
+	for _, pvc := range resources.pvcs {
+		if _, err = apply(pvcClient, pvc.Name, pvc); err != nil {
+			return fmt.Errorf("applying volume %s: %w", pvc.Name, err)
 		}
+	}
 
-		if pipeConfig.Metrics {
-			metricsPort := metricsBasePort + i
-			envs = append(envs, apiv1.EnvVar{Name: "METRICS", Value: "true"}, apiv1.EnvVar{Name: "METRICS_PORT", Value: strconv.Itoa(metricsPort)})
-			ports = append(ports, apiv1.ContainerPort{
-				Name:          "metrics-" + strconv.Itoa(i),
-				ContainerPort: int32(metricsPort),
-			})
+	for i, deployment := range resources.deployments {
+		if _, err = apply(deploymentsClient, deployment.Name, deployment); err != nil {
+			return fmt.Errorf("applying deployment %s: %w", deployment.Name, err)
+		}
+		if vpa := resources.vpas[i]; vpa != nil {
+			if _, err = apply(k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId), vpa.Name, vpa); err != nil {
+				return fmt.Errorf("applying autoscaler %s: %w", vpa.Name, err)
+			}
+		}
+		if hpa := resources.hpas[i]; hpa != nil {
+			if _, err = apply(horizontalAutoscalerClient, hpa.Name, hpa); err != nil {
+				return fmt.Errorf("applying autoscaler %s: %w", hpa.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PlanOperators returns the manifests CreateOperators would create without applying them.
+func (k *Kubernetes) PlanOperators(pipelineId string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) (planned []lib.PlannedResource, err error) {
+	resources := k.makePipelineResources(pipelineId, "", inputs, pipeConfig)
+	planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindConfigMap, Name: resources.root.Name, Manifest: resources.root})
+	for _, pvc := range resources.pvcs {
+		planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindPersistentVolumeClaim, Name: pvc.Name, Manifest: pvc})
+	}
+	for i, deployment := range resources.deployments {
+		planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindDeployment, Name: deployment.Name, Manifest: deployment})
+		if vpa := resources.vpas[i]; vpa != nil {
+			planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindVerticalPodAutoscaler, Name: vpa.Name, Manifest: vpa})
+		}
+		if hpa := resources.hpas[i]; hpa != nil {
+			planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindHorizontalPodAutoscaler, Name: hpa.Name, Manifest: hpa})
+		}
+	}
+	return
+}
+
+// makePipelineResources builds the objects for the cloud operators of a pipeline. In the pipeline deployment mode
+// all operators run in the deployment pipeline-<pipelineId>, in the operator mode every operator runs in a deployment
+// named like its volume, operator-<pipelineId>-<operatorId[0:8]>. A non-empty version is appended as --<version>
+// to the names, so that the deployments can run next to the current ones. A deployment of operators persisting
+// data always runs a single replica.
+func (k *Kubernetes) makePipelineResources(pipelineId, version string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) (resources pipelineResources) {
+	var containers []apiv1.Container
+	var volumes []apiv1.Volume
+	labels := map[string]string{
+		LabelFlowId:     pipeConfig.FlowId,
+		LabelPipelineId: pipelineId,
+		LabelUser:       pipeConfig.UserId,
+	}
+	selector := map[string]string{
+		LabelPipelineId: pipelineId,
+	}
+	resources.root = makeRoot(pipelineId, labels, inputs, pipeConfig)
+	if version != "" {
+		labels[LabelPipelineVersion] = version
+		selector[LabelPipelineVersion] = version
+	}
+
+	for i, operator := range inputs {
+		container, volume := k.makeContainer(pipelineId, i, operator, pipeConfig)
+		if volume != nil {
+			resources.pvcs = append(resources.pvcs, k.makePVC(volume.Name, "50M", labels))
 		}
-		if operator.OutputTopic != "" {
-			envs = append(envs, apiv1.EnvVar{Name: "OUTPUT", Value: operator.OutputTopic})
+		if pipeConfig.DeploymentMode == lib.DeploymentModeOperator {
+			operatorLabels := maps.Clone(labels)
+			operatorLabels[LabelOperatorId] = operator.Id
+			operatorSelector := maps.Clone(selector)
+			operatorSelector[LabelOperatorId] = operator.Id
+			name := operatorDeploymentName(pipelineId, operator, version)
+			var scaling lib.OperatorScaling
+			if !operator.PersistData {
+				scaling = pipeConfig.OperatorResources(operator.Id).Scaling
+			}
+			resources.deployments = append(resources.deployments, makeDeployment(name, operatorLabels, operatorSelector, []apiv1.Container{container}, volumeList(volume), scaling.InitialReplicas()))
+			resources.vpas = append(resources.vpas, k.makeVPA(name, operatorLabels, []pipe_lib.Operator{operator}, pipeConfig, scaling))
+			resources.hpas = append(resources.hpas, k.makeHPA(name, operatorLabels, scaling, []pipe_lib.Operator{operator}))
+			continue
 		}
+		containers = append(containers, container)
+		volumes = append(volumes, volumeList(volume)...)
+	}
 
+	if pipeConfig.DeploymentMode != lib.DeploymentModeOperator {
+		name := deploymentName(pipelineId, version)
+		scaling := pipelineScaling(pipelineId, inputs, pipeConfig)
+		resources.deployments = append(resources.deployments, makeDeployment(name, labels, selector, containers, volumes, scaling.InitialReplicas()))
+		resources.vpas = append(resources.vpas, k.makeVPA(name, labels, inputs, pipeConfig, scaling))
+		resources.hpas = append(resources.hpas, k.makeHPA(name, labels, scaling, inputs))
+	}
+	return
+}
+
+// pipelineScaling returns the scaling of a deployment running all operators of a pipeline. All operators
+// have to share the same scaling and none of them may persist data, otherwise the deployment is not scaled.
+func pipelineScaling(pipelineId string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) (scaling lib.OperatorScaling) {
+	for i, operator := range inputs {
+		operatorScaling := pipeConfig.OperatorResources(operator.Id).Scaling
 		if operator.PersistData {
-			volumeName := getOperatorName(pipelineId, operator)[0]
-			pvc := k.makePVC(volumeName, "50M")
-			_, err = pvcClient.Create(context.TODO(), pvc, metav1.CreateOptions{})
-			volumeMounts = append(volumeMounts, apiv1.VolumeMount{
-				Name:      volumeName,
-				MountPath: "/opt/data",
-			})
-			volumes = append(volumes, apiv1.Volume{
-				Name: volumeName,
-				VolumeSource: apiv1.VolumeSource{
-					PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
-						ClaimName: volumeName,
-						ReadOnly:  false,
-					},
-				},
-			})
+			operatorScaling = lib.OperatorScaling{}
+		}
+		if i == 0 {
+			scaling = operatorScaling
+			continue
 		}
+		if operatorScaling != scaling {
+			util.Logger.Warn("operators of pipeline do not share their scaling, running a single replica", "pipeline", pipelineId)
+			return lib.OperatorScaling{}
+		}
+	}
+	return
+}
 
-		container := apiv1.Container{
-			Name:            operator.OperatorId + "--" + operator.Id,
-			Image:           operator.ImageId,
-			ImagePullPolicy: "Always",
-			Env:             envs,
-			Ports:           ports,
-			VolumeMounts:    volumeMounts,
-			Resources: apiv1.ResourceRequirements{
-				Limits: apiv1.ResourceList{
-					apiv1.ResourceCPU:    resource.MustParse("500m"),
-					apiv1.ResourceMemory: resource.MustParse("512Mi"),
-				},
-				Requests: apiv1.ResourceList{
-					apiv1.ResourceCPU:    resource.MustParse("100m"),
-					apiv1.ResourceMemory: resource.MustParse("128Mi"),
+// makeContainer builds the container of the i-th operator of a pipeline and its volume, if the operator persists data.
+func (k *Kubernetes) makeContainer(pipelineId string, i int, operator pipe_lib.Operator, pipeConfig lib.PipelineConfig) (container apiv1.Container, volume *apiv1.Volume) {
+	metricsBasePort := 8080
+	var ports []apiv1.ContainerPort
+	var volumeMounts []apiv1.VolumeMount
+	operatorRequestConfig, _ := json.Marshal(lib.OperatorRequestConfig{Config: operator.Config, InputTopics: operator.InputTopics})
+	envs := []apiv1.EnvVar{
+		{
+			Name:  "ZK_QUORUM",
+			Value: k.r2cfg.Zookeeper,
+		},
+		{
+			Name:  "CONFIG_BOOTSTRAP_SERVERS",
+			Value: k.r2cfg.KafkaBootstrap,
+		},
+		{
+			Name:  "CONFIG_APPLICATION_ID",
+			Value: "analytics-" + operator.ApplicationId.String(),
+		},
+		{
+			Name:  "PIPELINE_ID",
+			Value: pipelineId,
+		},
+		{
+			Name:  "OPERATOR_ID",
+			Value: operator.Id,
+		},
+		{
+			Name:  "WINDOW_TIME",
+			Value: strconv.Itoa(pipeConfig.WindowTime),
+		},
+		{
+			Name:  "JOIN_STRATEGY",
+			Value: pipeConfig.MergeStrategy,
+		},
+		{
+			Name:  "CONFIG",
+			Value: string(operatorRequestConfig),
+		},
+		{
+			Name:  "DEVICE_ID_PATH",
+			Value: "device_id",
+		},
+		{
+			Name:  "CONSUMER_AUTO_OFFSET_RESET_CONFIG",
+			Value: pipeConfig.ConsumerOffset,
+		},
+		{
+			Name:  "USER_ID",
+			Value: pipeConfig.UserId,
+		},
+	}
+
+	if pipeConfig.Metrics {
+		metricsPort := metricsBasePort + i
+		envs = append(envs, apiv1.EnvVar{Name: "METRICS", Value: "true"}, apiv1.EnvVar{Name: "METRICS_PORT", Value: strconv.Itoa(metricsPort)})
+		ports = append(ports, apiv1.ContainerPort{
+			Name:          "metrics-" + strconv.Itoa(i),
+			ContainerPort: int32(metricsPort),
+		})
+	}
+	if operator.OutputTopic != "" {
+		envs = append(envs, apiv1.EnvVar{Name: "OUTPUT", Value: operator.OutputTopic})
+	}
+
+	if operator.PersistData {
+		volumeName := getOperatorName(pipelineId, operator)[0]
+		volumeMounts = append(volumeMounts, apiv1.VolumeMount{
+			Name:      volumeName,
+			MountPath: "/opt/data",
+		})
+		volume = &apiv1.Volume{
+			Name: volumeName,
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volumeName,
+					ReadOnly:  false,
 				},
 			},
 		}
-		containers = append(containers, container)
 	}
 
-	deployment := &appsv1.Deployment{
+	container = apiv1.Container{
+		Name:            ContainerName(operator),
+		Image:           operator.ImageId,
+		ImagePullPolicy: "Always",
+		Env:             envs,
+		Ports:           ports,
+		VolumeMounts:    volumeMounts,
+		Resources:       containerResources(pipeConfig.OperatorResources(operator.Id)),
+	}
+	return
+}
+
+func volumeList(volume *apiv1.Volume) []apiv1.Volume {
+	if volume == nil {
+		return nil
+	}
+	return []apiv1.Volume{*volume}
+}
+
+func makeDeployment(name string, labels, selector map[string]string, containers []apiv1.Container, volumes []apiv1.Volume, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: lib.ResourceKindDeployment},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1],
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{AnnotationReplicas: strconv.Itoa(int(replicas))},
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.To[int32](1),
+			Replicas: ptr.To(replicas),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"pipelineId": pipelineId,
-				},
+				MatchLabels: selector,
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"flowId":     pipeConfig.FlowId,
-						"pipelineId": pipelineId,
-						"user":       pipeConfig.UserId,
-					},
+					Labels: labels,
 				},
 				Spec: apiv1.PodSpec{
 					Volumes:    volumes,
@@ -214,134 +384,282 @@ func (k *Kubernetes) CreateOperators(pipelineId string, inputs []pipe_lib.Operat
 			},
 		},
 	}
+}
 
-	// Create Deployment
-	util.Logger.Debug("creating deployment")
-	result, err := deploymentsClient.Create(context.TODO(), deployment, metav1.CreateOptions{})
-	if err != nil {
-		return
+// makeHPA builds the horizontal pod autoscaler of the deployment name running operators, or returns nil if it is
+// not autoscaled. The consumer lag is read per operator from the external metric configured in the driver,
+// labeled with the consumer group of the operator.
+func (k *Kubernetes) makeHPA(name string, labels map[string]string, scaling lib.OperatorScaling, operators []pipe_lib.Operator) *autoscalingv2.HorizontalPodAutoscaler {
+	if !scaling.Autoscaled() {
+		return nil
 	}
-	util.Logger.Debug(fmt.Sprintf("created deployment %s", result.GetObjectMeta().GetName()))
-
-	// Create Vertical Pod Autoscaler
-	updateAutoMode := v1.UpdateModeRecreate
-	vpa := &v1.VerticalPodAutoscaler{
+	var metrics []autoscalingv2.MetricSpec
+	if scaling.TargetCpuUtilization > 0 {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: apiv1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(scaling.TargetCpuUtilization),
+				},
+			},
+		})
+	}
+	if scaling.TargetConsumerLag > 0 {
+		for _, operator := range operators {
+			metrics = append(metrics, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: k.r2cfg.ConsumerLagMetric,
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+							"consumergroup": "analytics-" + operator.ApplicationId.String(),
+						}},
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(scaling.TargetConsumerLag, resource.DecimalSI),
+					},
+				},
+			})
+		}
+	}
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: autoscalingv2.SchemeGroupVersion.String(), Kind: lib.ResourceKindHorizontalPodAutoscaler},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1] + "-vpa",
+			Name:   name + hpaSuffix,
+			Labels: labels,
 		},
-		Spec: v1.VerticalPodAutoscalerSpec{
-			TargetRef:    &autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1]},
-			UpdatePolicy: &v1.PodUpdatePolicy{UpdateMode: &updateAutoMode},
-			ResourcePolicy: &v1.PodResourcePolicy{ContainerPolicies: []v1.ContainerResourcePolicy{{
-				ContainerName: "*",
-				MaxAllowed: apiv1.ResourceList{
-					apiv1.ResourceCPU:    resource.MustParse("1000m"),
-					apiv1.ResourceMemory: resource.MustParse("4000Mi"),
-				},
-			}}},
-			Recommenders: nil,
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name},
+			MinReplicas:    ptr.To(scaling.MinReplicas),
+			MaxReplicas:    scaling.MaxReplicas,
+			Metrics:        metrics,
 		},
 	}
+}
 
-	util.Logger.Debug("creating autoscaler")
-	verticalAutoscalerClient := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId)
-	vpaResult, err := verticalAutoscalerClient.Create(context.TODO(), vpa, metav1.CreateOptions{})
-	if err != nil {
-		return
+// containerResources converts the resources of an operator, they are validated when the pipeline is started or updated.
+func containerResources(resources lib.OperatorResources) apiv1.ResourceRequirements {
+	return apiv1.ResourceRequirements{
+		Limits: apiv1.ResourceList{
+			apiv1.ResourceCPU:    resource.MustParse(resources.CpuLimit),
+			apiv1.ResourceMemory: resource.MustParse(resources.MemoryLimit),
+		},
+		Requests: apiv1.ResourceList{
+			apiv1.ResourceCPU:    resource.MustParse(resources.CpuRequest),
+			apiv1.ResourceMemory: resource.MustParse(resources.MemoryRequest),
+		},
 	}
-	util.Logger.Debug(fmt.Sprintf("created vpa %s", vpaResult.GetObjectMeta().GetName()))
-	return
 }
 
 func (k *Kubernetes) DeleteOperator(string, pipe_lib.Operator) (err error) {
 	return
 }
 
+// DeleteOperators deletes the root object of the pipeline and with it all objects it owns, it returns once they are gone.
+// Pipelines created without a root object have the volumes of the operators and every deployment version deleted one by one.
 func (k *Kubernetes) DeleteOperators(pipelineId string, operators []pipe_lib.Operator) (err error) {
-	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+	deleted, err := k.deleteRoot(pipelineId)
+	if err != nil || deleted {
+		return
+	}
+
 	pvcClient := k.clientset.CoreV1().PersistentVolumeClaims(k.r2cfg.NamespaceId)
-	verticalAutoscalerClient := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId)
-	verticalAutoscalerCheckpointClient := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalerCheckpoints(k.r2cfg.NamespaceId)
 
 	for _, operator := range operators {
 		if operator.PersistData {
 			volumeName := getOperatorName(pipelineId, operator)[0]
 			util.Logger.Debug("deleting volume " + volumeName)
 			err = pvcClient.Delete(context.TODO(), volumeName, metav1.DeleteOptions{})
-			util.Logger.Debug(fmt.Sprintf("deleted volume %s", volumeName))
-		}
-		autoscalerCheckpointId := getOperatorName(pipelineId, operator)[1] + "-vpa-" + operator.OperatorId + "--" + operator.Id
-		util.Logger.Debug("try to delete autoscaler checkpoint: " + autoscalerCheckpointId)
-		err = verticalAutoscalerCheckpointClient.Delete(context.TODO(), autoscalerCheckpointId, metav1.DeleteOptions{})
-		if err != nil {
-			if k8s_errors.IsNotFound(err) {
-				util.Logger.Debug("autoscaler checkpoint not found: " + autoscalerCheckpointId)
+			if err != nil {
+				if k8s_errors.IsNotFound(err) {
+					util.Logger.Debug("volume not found: " + volumeName)
+				} else {
+					return
+				}
 			} else {
-				return
+				util.Logger.Debug(fmt.Sprintf("deleted volume %s", volumeName))
 			}
-		} else {
-			util.Logger.Debug("deleted autoscaler checkpoint: " + autoscalerCheckpointId)
 		}
 	}
 
-	util.Logger.Debug("deleting deployment " + pipelineId)
+	versions, err := k.GetPipelineVersions(pipelineId)
+	if err != nil {
+		return
+	}
+	if len(versions) == 0 {
+		// still remove a left over autoscaler
+		versions = []string{""}
+	}
+	for _, version := range versions {
+		if err = k.DeleteOperatorsVersion(pipelineId, version, operators); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// DeleteOperatorsVersion deletes the deployments of a single version of the pipeline with their autoscalers,
+// whichever deployment mode they were created in. Volumes are shared by all versions and not deleted.
+func (k *Kubernetes) DeleteOperatorsVersion(pipelineId, version string, operators []pipe_lib.Operator) (err error) {
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return
+	}
+	found := false
+	for _, deployment := range deployments {
+		if deployment.Labels[LabelPipelineVersion] != version {
+			continue
+		}
+		found = true
+		var containers []string
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			containers = append(containers, container.Name)
+		}
+		if err = k.deleteDeployment(deployment.Name, containers); err != nil {
+			return
+		}
+	}
+	if found {
+		return
+	}
+	// still remove a left over autoscaler
+	var containers []string
+	for _, operator := range operators {
+		containers = append(containers, ContainerName(operator))
+	}
+	return k.deleteDeployment(deploymentName(pipelineId, version), containers)
+}
+
+// deleteDeployment deletes a deployment, its autoscalers and the autoscaler checkpoints of its containers.
+func (k *Kubernetes) deleteDeployment(name string, containers []string) (err error) {
+	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+
+	util.Logger.Debug("deleting deployment " + name)
 	deletePolicy := metav1.DeletePropagationForeground
 
-	err = deploymentsClient.Delete(context.TODO(), getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1], metav1.DeleteOptions{
+	err = deploymentsClient.Delete(context.TODO(), name, metav1.DeleteOptions{
 		PropagationPolicy: &deletePolicy,
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			util.Logger.Debug("deployment not found: " + getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1])
+			util.Logger.Debug("deployment not found: " + name)
+		} else {
+			return
+		}
+	} else {
+		util.Logger.Debug(fmt.Sprintf("deleted deployment %s", name))
+	}
+
+	if err = k.deleteVPA(name, containers); err != nil {
+		return
+	}
+
+	err = k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.r2cfg.NamespaceId).Delete(context.TODO(), name+hpaSuffix, metav1.DeleteOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			err = nil
 		} else {
 			return
 		}
 	} else {
-		util.Logger.Debug(fmt.Sprintf("deleted deployment %s", pipelineId))
+		util.Logger.Debug(fmt.Sprintf("deleted hpa %s", name+hpaSuffix))
+	}
+	return
+}
+
+// deleteVPA deletes the vertical autoscaler of the deployment name and the checkpoints of its containers,
+// if the cluster provides the vertical autoscaler.
+func (k *Kubernetes) deleteVPA(name string, containers []string) (err error) {
+	if !k.vpaAvailable {
+		return nil
+	}
+	verticalAutoscalerClient := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId)
+	verticalAutoscalerCheckpointClient := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalerCheckpoints(k.r2cfg.NamespaceId)
+
+	for _, container := range containers {
+		autoscalerCheckpointId := name + vpaSuffix + "-" + container
+		util.Logger.Debug("try to delete autoscaler checkpoint: " + autoscalerCheckpointId)
+		err = verticalAutoscalerCheckpointClient.Delete(context.TODO(), autoscalerCheckpointId, metav1.DeleteOptions{})
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				util.Logger.Debug("autoscaler checkpoint not found: " + autoscalerCheckpointId)
+			} else {
+				return
+			}
+		} else {
+			util.Logger.Debug("deleted autoscaler checkpoint: " + autoscalerCheckpointId)
+		}
 	}
 
-	util.Logger.Debug("deleting autoscaler " + pipelineId)
-	err = verticalAutoscalerClient.Delete(context.TODO(), getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1]+"-vpa", metav1.DeleteOptions{})
+	util.Logger.Debug("deleting autoscaler " + name)
+	err = verticalAutoscalerClient.Delete(context.TODO(), name+vpaSuffix, metav1.DeleteOptions{})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			util.Logger.Debug("autoscaler not found: " + pipelineId)
+			util.Logger.Debug("autoscaler not found: " + name)
+			err = nil
 		} else {
 			return
 		}
 	} else {
-		util.Logger.Debug(fmt.Sprintf("deleted autoscaler %s", pipelineId))
+		util.Logger.Debug(fmt.Sprintf("deleted autoscaler %s", name))
 	}
 	return
 }
 
+// GetPipelineStatus combines the status of all deployments of the pipeline and adds its recent warning events.
 func (k *Kubernetes) GetPipelineStatus(pipelineId string) (pipeStatus lib.PipelineStatus, err error) {
-	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
-	pipe, err := deploymentsClient.Get(context.TODO(), getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1], metav1.GetOptions{})
+	deployments, err := k.cachedPipelineDeployments(pipelineId)
 	if err != nil {
 		return
 	}
-	pipeStatus = lib.PipelineStatus{
-		Running:       pipe.Status.AvailableReplicas > 0 && pipe.Status.UnavailableReplicas == 0,
-		Transitioning: pipe.Status.UnavailableReplicas > 0,
-		Message:       "",
+	if len(deployments) == 0 {
+		err = k8s_errors.NewNotFound(appsv1.Resource("deployments"), deploymentName(pipelineId, ""))
+		return
 	}
+	pipeStatus = pipelineStatus(deployments)
+	k.addEvents(&pipeStatus, pipelineId)
 	return pipeStatus, err
 }
 
+// GetPipelinesStatus returns the status of every pipeline deployment in the namespace, served from the cache once it synced.
+// The deployments of a pipeline are combined and reported as pipeline-<pipelineId>.
 func (k *Kubernetes) GetPipelinesStatus() (pipeStatus []lib.PipelineStatus, err error) {
-	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
-	pipes, err := deploymentsClient.List(context.TODO(), metav1.ListOptions{})
+	var deployments []appsv1.Deployment
+	if k.deployments.synced() {
+		deployments, err = k.deployments.list(labels.Everything())
+	} else {
+		var pipes *appsv1.DeploymentList
+		pipes, err = k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{LabelSelector: LabelPipelineId})
+		if pipes != nil {
+			deployments = pipes.Items
+		}
+	}
 	if err != nil {
 		return
 	}
+	slices.SortFunc(deployments, func(a, b appsv1.Deployment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
-	for _, deployment := range pipes.Items {
-		pipeStatus = append(pipeStatus, lib.PipelineStatus{
-			Running:       deployment.Status.AvailableReplicas > 0 && deployment.Status.UnavailableReplicas == 0,
-			Transitioning: deployment.Status.UnavailableReplicas > 0,
-			Message:       "",
-			Name:          deployment.Name,
-		})
+	var names []string
+	grouped := map[string][]appsv1.Deployment{}
+	for _, deployment := range deployments {
+		name := deployment.Name
+		if pipelineId := deploymentPipelineId(deployment); pipelineId != "" {
+			name = deploymentName(pipelineId, "")
+		}
+		if _, ok := grouped[name]; !ok {
+			names = append(names, name)
+		}
+		grouped[name] = append(grouped[name], deployment)
+	}
+	for _, name := range names {
+		status := pipelineStatus(grouped[name])
+		status.Name = name
+		pipeStatus = append(pipeStatus, status)
 	}
 	return
 }
@@ -350,12 +668,14 @@ func getOperatorName(pipelineId string, operator pipe_lib.Operator) []string {
 	return []string{"operator-" + pipelineId + "-" + operator.Id[0:8], "pipeline-" + pipelineId}
 }
 
-func (k *Kubernetes) makePVC(name string, size string) *apiv1.PersistentVolumeClaim {
+func (k *Kubernetes) makePVC(name string, size string, labels map[string]string) *apiv1.PersistentVolumeClaim {
 	fs := apiv1.PersistentVolumeFilesystem
 	pvc := apiv1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiv1.SchemeGroupVersion.String(), Kind: lib.ResourceKindPersistentVolumeClaim},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: k.r2cfg.NamespaceId,
+			Labels:    labels,
 		},
 		Spec: apiv1.PersistentVolumeClaimSpec{
 			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
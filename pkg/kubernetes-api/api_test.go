@@ -18,7 +18,7 @@ func getClient() (client *Kubernetes, err error) {
 		return
 	}
 	util.InitStructLogger("debug")
-	client, err = NewKubernetes(&cfg.Rancher2, true)
+	client, err = NewKubernetes(&cfg.Rancher2, cfg.VPA, true)
 	if err != nil {
 		return
 	}
@@ -32,7 +32,7 @@ func TestKubernetes_createClient(t *testing.T) {
 		return
 	}
 	util.InitStructLogger("debug")
-	_, err = NewKubernetes(&cfg.Rancher2, true)
+	_, err = NewKubernetes(&cfg.Rancher2, cfg.VPA, true)
 	if err != nil {
 		t.Error(err.Error())
 		return
@@ -126,3 +126,88 @@ func TestKubernetes_GetPipelineStatus(t *testing.T) {
 		return
 	}
 }
+
+func TestKubernetes_makePipelineResources(t *testing.T) {
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{}, vpaCfg: config.VPAConfig{Enabled: true, UpdateMode: "Recreate"}, vpaAvailable: true}
+	ops := []pipe.Operator{
+		{Id: "11111111-op", OperatorId: "a", PersistData: true},
+		{Id: "22222222-op", OperatorId: "b"},
+	}
+
+	resources := k.makePipelineResources(testPipeId, "", ops, lib.PipelineConfig{DeploymentMode: lib.DeploymentModePipeline})
+	if len(resources.deployments) != 1 || len(resources.vpas) != 1 || len(resources.pvcs) != 1 {
+		t.Fatalf("expected one deployment, autoscaler and volume, got %+v", resources)
+	}
+	if deployment := resources.deployments[0]; deployment.Name != deploymentName(testPipeId, "") || len(deployment.Spec.Template.Spec.Containers) != 2 {
+		t.Errorf("unexpected deployment %s with %d containers", deployment.Name, len(deployment.Spec.Template.Spec.Containers))
+	}
+
+	resources = k.makePipelineResources(testPipeId, "v2", ops, lib.PipelineConfig{DeploymentMode: lib.DeploymentModeOperator})
+	if len(resources.deployments) != 2 || len(resources.vpas) != 2 || len(resources.pvcs) != 1 {
+		t.Fatalf("expected a deployment and autoscaler per operator, got %+v", resources)
+	}
+	for i, deployment := range resources.deployments {
+		name := getOperatorName(testPipeId, ops[i])[0] + versionSeparator + "v2"
+		if deployment.Name != name || resources.vpas[i].Spec.TargetRef.Name != name {
+			t.Errorf("expected deployment and autoscaler target %s, got %s and %s", name, deployment.Name, resources.vpas[i].Spec.TargetRef.Name)
+		}
+		if selector := deployment.Spec.Selector.MatchLabels; selector[LabelOperatorId] != ops[i].Id || selector[LabelPipelineVersion] != "v2" {
+			t.Errorf("unexpected selector %v", selector)
+		}
+		if containers := deployment.Spec.Template.Spec.Containers; len(containers) != 1 || containers[0].Name != ContainerName(ops[i]) {
+			t.Errorf("expected only the container of %s, got %+v", ops[i].Id, containers)
+		}
+	}
+	if volumes := resources.deployments[0].Spec.Template.Spec.Volumes; len(volumes) != 1 || volumes[0].Name != resources.pvcs[0].Name {
+		t.Errorf("expected volume of the first operator, got %+v", volumes)
+	}
+	if len(resources.deployments[1].Spec.Template.Spec.Volumes) != 0 {
+		t.Error("expected no volume for the second operator")
+	}
+}
+
+func TestKubernetes_makePipelineResourcesScaling(t *testing.T) {
+	util.InitStructLogger("error")
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{ConsumerLagMetric: "lag"}, vpaCfg: config.VPAConfig{Enabled: true, UpdateMode: "Recreate"}, vpaAvailable: true}
+	ops := []pipe.Operator{
+		{Id: "11111111-op", OperatorId: "a"},
+		{Id: "22222222-op", OperatorId: "b"},
+	}
+	scaling := lib.OperatorScaling{MinReplicas: 2, MaxReplicas: 4, TargetCpuUtilization: 80, TargetConsumerLag: 100}
+	pipeConfig := lib.PipelineConfig{DeploymentMode: lib.DeploymentModePipeline, Resources: map[string]lib.OperatorResources{}}
+	for _, op := range ops {
+		resources := lib.ResourceProfiles[lib.ResourceProfileSmall]
+		resources.Scaling = scaling
+		pipeConfig.Resources[op.Id] = resources
+	}
+
+	resources := k.makePipelineResources(testPipeId, "", ops, pipeConfig)
+	if replicas := *resources.deployments[0].Spec.Replicas; replicas != 2 || initialReplicas(*resources.deployments[0]) != 2 {
+		t.Errorf("expected min replicas, got %d", replicas)
+	}
+	hpa := resources.hpas[0]
+	if hpa == nil || hpa.Spec.ScaleTargetRef.Name != resources.deployments[0].Name || hpa.Spec.MaxReplicas != 4 {
+		t.Fatalf("unexpected horizontal autoscaler %+v", hpa)
+	}
+	if len(hpa.Spec.Metrics) != 3 {
+		t.Errorf("expected a cpu metric and a lag metric per operator, got %+v", hpa.Spec.Metrics)
+	}
+	if controlled := resources.vpas[0].Spec.ResourcePolicy.ContainerPolicies[0].ControlledResources; controlled == nil || len(*controlled) != 1 {
+		t.Error("expected vertical autoscaler to only control memory")
+	}
+
+	ops[1].PersistData = true
+	resources = k.makePipelineResources(testPipeId, "", ops, pipeConfig)
+	if *resources.deployments[0].Spec.Replicas != 1 || resources.hpas[0] != nil {
+		t.Error("expected pipeline with an operator persisting data not to be scaled")
+	}
+
+	pipeConfig.DeploymentMode = lib.DeploymentModeOperator
+	resources = k.makePipelineResources(testPipeId, "", ops, pipeConfig)
+	if resources.hpas[0] == nil || len(resources.hpas[0].Spec.Metrics) != 2 {
+		t.Errorf("expected horizontal autoscaler of the first operator, got %+v", resources.hpas[0])
+	}
+	if *resources.deployments[1].Spec.Replicas != 1 || resources.hpas[1] != nil {
+		t.Error("expected operator persisting data not to be scaled")
+	}
+}
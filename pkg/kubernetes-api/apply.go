@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// patcher is implemented by the typed clients of all resources the driver applies.
+type patcher[T any] interface {
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+}
+
+// apply creates or updates obj by server-side apply, so that repeated calls converge instead of failing
+// because the object already exists. Conflicting fields of other managers are taken over. An object which is
+// still being deleted is an error, as applying it would not prevent its deletion.
+func apply[T metav1.Object](client patcher[T], name string, obj any) (result T, err error) {
+	data, err := applyPatch(obj)
+	if err != nil {
+		return
+	}
+	util.Logger.Debug("applying " + name)
+	result, err = client.Patch(context.TODO(), name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        ptr.To(true),
+	})
+	if err == nil && result.GetDeletionTimestamp() != nil {
+		err = fmt.Errorf("%s is still being deleted", name)
+	}
+	return
+}
+
+// applyPatch marshals obj without its status, which is set by the cluster and must not be owned by the driver.
+func applyPatch(obj any) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "status")
+	return json.Marshal(fields)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApply(t *testing.T) {
+	util.InitStructLogger("error")
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{NamespaceId: "ns"}}
+	ops := []pipe.Operator{{Id: "11111111-op", OperatorId: "a", PersistData: true}}
+	pipeConfig := lib.PipelineConfig{UserId: "user"}
+	clientset := fake.NewClientset()
+	deploymentsClient := clientset.AppsV1().Deployments("ns")
+	pvcClient := clientset.CoreV1().PersistentVolumeClaims("ns")
+
+	for range 2 {
+		resources := k.makePipelineResources(testPipeId, "", ops, pipeConfig)
+		if _, err := apply(pvcClient, resources.pvcs[0].Name, resources.pvcs[0]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := apply(deploymentsClient, resources.deployments[0].Name, resources.deployments[0]); err != nil {
+			t.Fatal(err)
+		}
+		pipeConfig.UserId = "other"
+	}
+	deployment, err := deploymentsClient.Get(context.TODO(), deploymentName(testPipeId, ""), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployment.Labels[LabelUser] != "other" {
+		t.Errorf("expected deployment to be updated by the second apply, got labels %v", deployment.Labels)
+	}
+	if pvcs, _ := pvcClient.List(context.TODO(), metav1.ListOptions{}); len(pvcs.Items) != 1 {
+		t.Errorf("expected a single volume, got %d", len(pvcs.Items))
+	}
+}
+
+func TestApplyDeleting(t *testing.T) {
+	util.InitStructLogger("error")
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{}}
+	deployment := k.makePipelineResources(testPipeId, "", []pipe.Operator{{Id: "11111111-op"}}, lib.PipelineConfig{}).deployments[0]
+	deleting := deployment.DeepCopy()
+	deleting.Namespace = "ns"
+	deleting.DeletionTimestamp = &metav1.Time{}
+	deleting.Finalizers = []string{metav1.FinalizerDeleteDependents}
+	clientset := fake.NewClientset(deleting)
+	if _, err := apply(clientset.AppsV1().Deployments("ns"), deployment.Name, deployment); err == nil {
+		t.Error("expected error for deployment which is still being deleted")
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{}}
+	deployment := k.makePipelineResources(testPipeId, "", []pipe.Operator{{Id: "11111111-op"}}, lib.PipelineConfig{}).deployments[0]
+	deployment.Status.ReadyReplicas = 1
+	data, err := applyPatch(deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["status"]; ok {
+		t.Error("expected status to be removed")
+	}
+	if fields["kind"] != lib.ResourceKindDeployment || fields["apiVersion"] != "apps/v1" {
+		t.Errorf("expected type of the object, got %v %v", fields["apiVersion"], fields["kind"])
+	}
+}
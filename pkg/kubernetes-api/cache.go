@@ -0,0 +1,134 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const cacheSyncTimeout = time.Minute
+
+// deploymentCache keeps the deployments labeled with a pipeline ID in memory, filled by a shared informer.
+// Until the informer has synced, reads have to go to the API server.
+type deploymentCache struct {
+	factory  informers.SharedInformerFactory
+	informer cache.SharedIndexInformer
+	lister   appslisters.DeploymentNamespaceLister
+}
+
+func newDeploymentCache(clientset kubernetes.Interface, namespace string) *deploymentCache {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = LabelPipelineId
+		}),
+	)
+	deployments := factory.Apps().V1().Deployments()
+	return &deploymentCache{
+		factory:  factory,
+		informer: deployments.Informer(),
+		lister:   deployments.Lister().Deployments(namespace),
+	}
+}
+
+// start runs the informer until ctx is done and waits until it synced, at most for timeout.
+func (c *deploymentCache) start(ctx context.Context, timeout time.Duration) error {
+	c.factory.Start(ctx.Done())
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), c.informer.HasSynced) {
+		return errors.New("deployment cache did not sync")
+	}
+	return nil
+}
+
+func (c *deploymentCache) synced() bool {
+	return c.informer.HasSynced()
+}
+
+// list returns copies of the cached deployments matching selector.
+func (c *deploymentCache) list(selector labels.Selector) ([]appsv1.Deployment, error) {
+	cached, err := c.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	deployments := make([]appsv1.Deployment, 0, len(cached))
+	for _, deployment := range cached {
+		deployments = append(deployments, *deployment.DeepCopy())
+	}
+	return deployments, nil
+}
+
+// StartCache labels deployments created without a pipeline ID label, so that the cache includes them,
+// and starts the deployment cache. Status requests are served from memory once it synced, if it does not sync
+// within a minute they are served by the API server until it does.
+func (k *Kubernetes) StartCache(ctx context.Context) {
+	k.labelDeployments(ctx)
+	if err := k.deployments.start(ctx, cacheSyncTimeout); err != nil {
+		util.Logger.Warn("status requests are served by the API server until the cache synced", "error", err)
+	}
+}
+
+// labelDeployments adds the pipeline ID label to pipeline deployments which only carry it in their selector.
+// Deployments which cannot be labeled, e.g. because the driver may not patch them, are logged and left out of
+// the status of all pipelines until they are recreated.
+func (k *Kubernetes) labelDeployments(ctx context.Context) {
+	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+	list, err := deploymentsClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		util.Logger.Warn("cannot list deployments to label", "error", err)
+		return
+	}
+	for _, deployment := range list.Items {
+		if _, ok := deployment.Labels[LabelPipelineId]; ok || !strings.HasPrefix(deployment.Name, deploymentPrefix) || deployment.Spec.Selector == nil {
+			continue
+		}
+		pipelineId := deployment.Spec.Selector.MatchLabels[LabelPipelineId]
+		if pipelineId == "" {
+			continue
+		}
+		patch := fmt.Appendf(nil, `{"metadata":{"labels":{%q:%q}}}`, LabelPipelineId, pipelineId)
+		if _, err = deploymentsClient.Patch(ctx, deployment.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			util.Logger.Warn("cannot label deployment with pipeline ID", "deployment", deployment.Name, "pipeline", pipelineId, "error", err)
+			continue
+		}
+		util.Logger.Info("labeled deployment with pipeline ID", "deployment", deployment.Name, "pipeline", pipelineId)
+	}
+}
+
+// cachedPipelineDeployments returns the deployments of the pipeline from the cache, or from the API server while it has not synced.
+// Operations changing deployments read them from the API server, as the cache may lag behind.
+func (k *Kubernetes) cachedPipelineDeployments(pipelineId string) ([]appsv1.Deployment, error) {
+	if !k.deployments.synced() {
+		return k.pipelineDeployments(pipelineId)
+	}
+	return k.deployments.list(labels.SelectorFromSet(labels.Set{LabelPipelineId: pipelineId}))
+}
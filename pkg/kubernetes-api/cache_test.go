@@ -0,0 +1,75 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentCache(t *testing.T) {
+	namespace := "analytics-pipelines"
+	deployment := func(name, pipelineId string) *appsv1.Deployment {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if pipelineId != "" {
+			d.Labels = map[string]string{LabelPipelineId: pipelineId}
+		}
+		return d
+	}
+	clientset := fake.NewClientset(
+		deployment(deploymentName("a", ""), "a"),
+		deployment(deploymentName("a", "v2"), "a"),
+		deployment(deploymentName("b", ""), "b"),
+		deployment("unrelated", ""),
+	)
+	c := newDeploymentCache(clientset, namespace)
+	if c.synced() {
+		t.Fatal("expected cache not to be synced before it started")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.start(ctx, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := c.list(labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected only the labeled deployments to be cached, got %d", len(all))
+	}
+	versions, err := c.list(labels.SelectorFromSet(labels.Set{LabelPipelineId: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Errorf("expected both versions of pipeline a, got %d", len(versions))
+	}
+
+	versions[0].Labels["modified"] = "true"
+	cached, _ := c.list(labels.SelectorFromSet(labels.Set{"modified": "true"}))
+	if len(cached) != 0 {
+		t.Error("expected the cache to return copies")
+	}
+}
@@ -19,3 +19,32 @@ package kubernetes_api
 const (
 	DummyOperatorId = "v3-123456789"
 )
+
+const (
+	LabelPipelineId = "pipelineId"
+	LabelFlowId     = "flowId"
+	LabelUser       = "user"
+	// LabelPipelineVersion is only set on versioned deployments created by blue/green updates.
+	LabelPipelineVersion = "pipelineVersion"
+	// LabelOperatorId is only set on deployments of a single operator in the operator deployment mode.
+	LabelOperatorId = "operatorId"
+)
+
+const (
+	deploymentPrefix = "pipeline-"
+	volumePrefix     = "operator-"
+	vpaSuffix        = "-vpa"
+	hpaSuffix        = "-hpa"
+	versionSeparator = "--"
+)
+
+// FieldManager owns the fields of all objects the driver applies.
+const FieldManager = "analytics-flow-engine"
+
+const reasonOOMKilled = "OOMKilled"
+
+// AnnotationReplicas holds the replicas a deployment is created with, so that it can be scaled up to them again after a pause.
+const AnnotationReplicas = "analytics-flow-engine/replicas"
+
+// AnnotationRestartedAt is the pod template annotation kubectl rollout restart sets.
+const AnnotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
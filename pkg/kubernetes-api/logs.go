@@ -0,0 +1,73 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	apiv1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetOperatorLogs streams the logs of the operator's container in the most recently created pod of the pipeline.
+func (k *Kubernetes) GetOperatorLogs(ctx context.Context, pipelineId string, operator pipe_lib.Operator, options lib.LogOptions) (io.ReadCloser, error) {
+	pods, err := k.pipelinePods(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	pod, ok := newestPod(pods, ContainerName(operator))
+	if !ok {
+		return nil, lib.NewNotFoundError(errors.New("no pod runs operator " + operator.Id))
+	}
+	logOptions := &apiv1.PodLogOptions{
+		Container: ContainerName(operator),
+		TailLines: options.TailLines,
+		Previous:  options.Previous,
+		Follow:    options.Follow,
+	}
+	if options.Since != nil {
+		logOptions.SinceTime = &metav1.Time{Time: *options.Since}
+	}
+	stream, err := k.clientset.CoreV1().Pods(k.r2cfg.NamespaceId).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if k8s_errors.IsBadRequest(err) {
+		// e.g. if previous logs are requested but the container never terminated
+		return nil, lib.NewInputError(err)
+	}
+	return stream, err
+}
+
+// newestPod returns the most recently created pod with the container.
+func newestPod(pods []apiv1.Pod, container string) (apiv1.Pod, bool) {
+	var candidates []apiv1.Pod
+	for _, pod := range pods {
+		if slices.ContainsFunc(pod.Spec.Containers, func(c apiv1.Container) bool { return c.Name == container }) {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		return apiv1.Pod{}, false
+	}
+	return slices.MaxFunc(candidates, func(a, b apiv1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	}), true
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewestPod(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := func(name string, age time.Duration, containers ...string) apiv1.Pod {
+		p := apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created.Add(-age))}}
+		for _, container := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, apiv1.Container{Name: container})
+		}
+		return p
+	}
+	pods := []apiv1.Pod{
+		pod("old", time.Hour, "op--a", "op--b"),
+		pod("new", time.Minute, "op--a"),
+		pod("newest", 0, "op--c"),
+	}
+	if p, ok := newestPod(pods, "op--a"); !ok || p.Name != "new" {
+		t.Errorf("expected pod new, got %s", p.Name)
+	}
+	if p, ok := newestPod(pods, "op--b"); !ok || p.Name != "old" {
+		t.Errorf("expected pod old, got %s", p.Name)
+	}
+	if _, ok := newestPod(pods, "op--d"); ok {
+		t.Error("expected no pod for unknown container")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetOperatorsStatus returns the container state of each operator in the pods of all deployment versions of the pipeline.
+func (k *Kubernetes) GetOperatorsStatus(pipelineId string, operators []pipe_lib.Operator) ([]lib.OperatorStatus, error) {
+	pods, err := k.pipelinePods(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	return OperatorsStatus(pods, operators), nil
+}
+
+// pipelinePods returns the pods of all deployments of the pipeline. The selector of an unversioned deployment
+// of all operators also matches the pods of the other deployments, so every pod is only returned once.
+func (k *Kubernetes) pipelinePods(pipelineId string) (pods []apiv1.Pod, err error) {
+	deployments, err := k.cachedPipelineDeployments(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		list, err := k.clientset.CoreV1().Pods(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range list.Items {
+			if !slices.ContainsFunc(pods, func(p apiv1.Pod) bool { return p.Name == pod.Name }) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
+// RestartOperator restarts the deployments of the operator. Only the operator deployment mode creates deployments of
+// single operators, otherwise all operators share the pods of the pipeline and restarting one is an input error.
+func (k *Kubernetes) RestartOperator(pipelineId string, operator pipe_lib.Operator) error {
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return err
+	}
+	deployments = slices.DeleteFunc(deployments, func(deployment appsv1.Deployment) bool {
+		return deployment.Labels[LabelOperatorId] != operator.Id
+	})
+	if len(deployments) == 0 {
+		return lib.NewInputError(fmt.Errorf("operator %s shares its pods with the other operators of the pipeline, restart the pipeline instead", operator.Id))
+	}
+	return k.restartDeployments(deployments)
+}
+
+// ContainerName returns the name of the container running operator.
+func ContainerName(operator pipe_lib.Operator) string {
+	return operator.OperatorId + "--" + operator.Id
+}
+
+// OperatorsStatus derives the status of each operator from the container statuses of the pods running it.
+// Operators without a container are not ready and have no state.
+func OperatorsStatus(pods []apiv1.Pod, operators []pipe_lib.Operator) []lib.OperatorStatus {
+	statuses := make([]lib.OperatorStatus, 0, len(operators))
+	for _, operator := range operators {
+		status := lib.OperatorStatus{OperatorId: operator.Id, Name: operator.Name, Image: operator.ImageId, Ready: true}
+		found := false
+		for _, pod := range pods {
+			for _, container := range pod.Status.ContainerStatuses {
+				if container.Name != ContainerName(operator) {
+					continue
+				}
+				found = true
+				addContainerStatus(&status, container)
+			}
+		}
+		status.Ready = status.Ready && found
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func addContainerStatus(status *lib.OperatorStatus, container apiv1.ContainerStatus) {
+	status.Ready = status.Ready && container.Ready
+	status.Image = container.Image
+	status.RestartCount += container.RestartCount
+	// a waiting or terminated container takes precedence over running ones
+	if status.State == "" || status.State == lib.ContainerStateRunning {
+		switch {
+		case container.State.Waiting != nil:
+			status.State = lib.ContainerStateWaiting
+			status.Reason = container.State.Waiting.Reason
+		case container.State.Terminated != nil:
+			status.State = lib.ContainerStateTerminated
+			status.Reason = container.State.Terminated.Reason
+		case container.State.Running != nil:
+			status.State = lib.ContainerStateRunning
+		}
+	}
+	if container.State.Terminated != nil && container.State.Terminated.Reason == reasonOOMKilled {
+		status.OOMKilled = true
+	}
+	if terminated := container.LastTerminationState.Terminated; terminated != nil {
+		status.LastTerminationReason = terminated.Reason
+		status.OOMKilled = status.OOMKilled || terminated.Reason == reasonOOMKilled
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestOperatorsStatus(t *testing.T) {
+	healthy := pipe.Operator{Id: "a", OperatorId: "base", Name: "healthy", ImageId: "image:1"}
+	crashing := pipe.Operator{Id: "b", OperatorId: "base", Name: "crashing", ImageId: "image:1"}
+	missing := pipe.Operator{Id: "c", OperatorId: "base", Name: "missing", ImageId: "image:1"}
+	pods := []apiv1.Pod{{Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{
+		{
+			Name:  ContainerName(healthy),
+			Image: "image:1",
+			Ready: true,
+			State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+		},
+		{
+			Name:                 ContainerName(crashing),
+			Image:                "image:1",
+			RestartCount:         3,
+			State:                apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: reasonOOMKilled}},
+		},
+	}}}}
+
+	statuses := OperatorsStatus(pods, []pipe.Operator{healthy, crashing, missing})
+	expected := []lib.OperatorStatus{
+		{OperatorId: "a", Name: "healthy", Image: "image:1", Ready: true, State: lib.ContainerStateRunning},
+		{OperatorId: "b", Name: "crashing", Image: "image:1", State: lib.ContainerStateWaiting, Reason: "CrashLoopBackOff", RestartCount: 3, LastTerminationReason: reasonOOMKilled, OOMKilled: true},
+		{OperatorId: "c", Name: "missing", Image: "image:1"},
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("expected %d statuses, got %d", len(expected), len(statuses))
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], statuses[i])
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPipelineResources lists all root and state objects, deployments, volumes, vertical and horizontal autoscalers and autoscaler
+// checkpoints in the namespace which belong to a pipeline, either by their pipelineId label or by their name. Objects
+// owned by a root object are left out, they are deleted with it. Vertical autoscalers and their checkpoints are only
+// listed if the cluster provides them.
+func (k *Kubernetes) GetPipelineResources() (resources []lib.PipelineResource, err error) {
+	configMaps, err := k.clientset.CoreV1().ConfigMaps(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{LabelSelector: LabelPipelineId})
+	if err != nil {
+		return
+	}
+	for _, configMap := range configMaps.Items {
+		pipelineId := configMap.Labels[LabelPipelineId]
+		if configMap.Name != rootName(pipelineId) && configMap.Name != stateName(pipelineId) {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindConfigMap,
+			Name:       configMap.Name,
+			PipelineId: pipelineId,
+			FlowId:     configMap.Labels[LabelFlowId],
+			UserId:     configMap.Labels[LabelUser],
+		})
+	}
+
+	deployments, err := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, deployment := range deployments.Items {
+		if ownedByRoot(&deployment) {
+			continue
+		}
+		labels := deployment.Spec.Template.Labels
+		pipelineId := labels[LabelPipelineId]
+		if pipelineId == "" {
+			pipelineId = pipelineIdFromDeploymentName(deployment.Name)
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindDeployment,
+			Name:       deployment.Name,
+			PipelineId: pipelineId,
+			FlowId:     labels[LabelFlowId],
+			UserId:     labels[LabelUser],
+		})
+	}
+
+	pvcs, err := k.clientset.CoreV1().PersistentVolumeClaims(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, pvc := range pvcs.Items {
+		if ownedByRoot(&pvc) {
+			continue
+		}
+		pipelineId := pvc.Labels[LabelPipelineId]
+		if pipelineId == "" {
+			pipelineId = pipelineIdFromVolumeName(pvc.Name)
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindPersistentVolumeClaim,
+			Name:       pvc.Name,
+			PipelineId: pipelineId,
+			FlowId:     pvc.Labels[LabelFlowId],
+			UserId:     pvc.Labels[LabelUser],
+		})
+	}
+
+	hpas, err := k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, hpa := range hpas.Items {
+		if ownedByRoot(&hpa) {
+			continue
+		}
+		pipelineId := hpa.Labels[LabelPipelineId]
+		if pipelineId == "" {
+			pipelineId = pipelineIdFromDeploymentName(strings.TrimSuffix(hpa.Name, hpaSuffix))
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindHorizontalPodAutoscaler,
+			Name:       hpa.Name,
+			PipelineId: pipelineId,
+			FlowId:     hpa.Labels[LabelFlowId],
+			UserId:     hpa.Labels[LabelUser],
+		})
+	}
+
+	if !k.vpaAvailable {
+		return
+	}
+
+	vpas, err := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, vpa := range vpas.Items {
+		if ownedByRoot(&vpa) {
+			continue
+		}
+		pipelineId := vpa.Labels[LabelPipelineId]
+		if pipelineId == "" {
+			pipelineId = pipelineIdFromDeploymentName(strings.TrimSuffix(vpa.Name, vpaSuffix))
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindVerticalPodAutoscaler,
+			Name:       vpa.Name,
+			PipelineId: pipelineId,
+			FlowId:     vpa.Labels[LabelFlowId],
+			UserId:     vpa.Labels[LabelUser],
+		})
+	}
+
+	checkpoints, err := k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalerCheckpoints(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+	for _, checkpoint := range checkpoints.Items {
+		if !strings.HasSuffix(checkpoint.Spec.VPAObjectName, vpaSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(checkpoint.Spec.VPAObjectName, vpaSuffix)
+		pipelineId := pipelineIdFromDeploymentName(name)
+		if pipelineId == "" {
+			// deployment of a single operator, named like its volume
+			name, _, _ = strings.Cut(name, versionSeparator)
+			pipelineId = pipelineIdFromVolumeName(name)
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindVerticalPodAutoscalerCheckpoint,
+			Name:       checkpoint.Name,
+			PipelineId: pipelineId,
+		})
+	}
+	return
+}
+
+// DeletePipelineResource deletes a single resource previously returned by GetPipelineResources.
+func (k *Kubernetes) DeletePipelineResource(resource lib.PipelineResource) (err error) {
+	util.Logger.Debug("deleting pipeline resource", "resource", resource)
+	if (resource.Kind == lib.ResourceKindVerticalPodAutoscaler || resource.Kind == lib.ResourceKindVerticalPodAutoscalerCheckpoint) && !k.vpaAvailable {
+		return lib.NewInputError(errors.New("vertical pod autoscaler not available in cluster"))
+	}
+	switch resource.Kind {
+	case lib.ResourceKindConfigMap:
+		deletePolicy := metav1.DeletePropagationForeground
+		err = k.clientset.CoreV1().ConfigMaps(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{
+			PropagationPolicy: &deletePolicy,
+		})
+	case lib.ResourceKindDeployment:
+		deletePolicy := metav1.DeletePropagationForeground
+		err = k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{
+			PropagationPolicy: &deletePolicy,
+		})
+	case lib.ResourceKindPersistentVolumeClaim:
+		err = k.clientset.CoreV1().PersistentVolumeClaims(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{})
+	case lib.ResourceKindVerticalPodAutoscaler:
+		err = k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalers(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{})
+	case lib.ResourceKindHorizontalPodAutoscaler:
+		err = k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{})
+	case lib.ResourceKindVerticalPodAutoscalerCheckpoint:
+		err = k.autoscalerClientset.AutoscalingV1().VerticalPodAutoscalerCheckpoints(k.r2cfg.NamespaceId).Delete(context.TODO(), resource.Name, metav1.DeleteOptions{})
+	default:
+		return lib.NewInputError(errors.New("unknown resource kind: " + resource.Kind))
+	}
+	if k8s_errors.IsNotFound(err) {
+		util.Logger.Debug("pipeline resource not found", "resource", resource)
+		return nil
+	}
+	return
+}
+
+// pipelineIdFromDeploymentName extracts the pipeline ID of a pipeline-<pipelineId> or pipeline-<pipelineId>--<version> name.
+func pipelineIdFromDeploymentName(name string) string {
+	if !strings.HasPrefix(name, deploymentPrefix) {
+		return ""
+	}
+	pipelineId, _, _ := strings.Cut(strings.TrimPrefix(name, deploymentPrefix), versionSeparator)
+	return pipelineId
+}
+
+// pipelineIdFromVolumeName extracts the pipeline ID of an operator-<pipelineId>-<operatorId[0:8]> name.
+func pipelineIdFromVolumeName(name string) string {
+	if !strings.HasPrefix(name, volumePrefix) {
+		return ""
+	}
+	trimmed := strings.TrimPrefix(name, volumePrefix)
+	if len(trimmed) < 10 || trimmed[len(trimmed)-9] != '-' {
+		return ""
+	}
+	return trimmed[:len(trimmed)-9]
+}
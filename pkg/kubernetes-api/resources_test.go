@@ -0,0 +1,48 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"testing"
+
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestKubernetes_pipelineIdFromResourceNames(t *testing.T) {
+	pipelineId := "0b6c2e4e-6f4c-4f8e-9a3b-1f2d3c4b5a69"
+	names := getOperatorName(pipelineId, pipe.Operator{Id: "6fc47542-dfee-4d6e-b352-dab9c91e5aed"})
+	if id := pipelineIdFromVolumeName(names[0]); id != pipelineId {
+		t.Errorf("volume name %s: expected %s, got %s", names[0], pipelineId, id)
+	}
+	if id := pipelineIdFromDeploymentName(names[1]); id != pipelineId {
+		t.Errorf("deployment name %s: expected %s, got %s", names[1], pipelineId, id)
+	}
+	if name := deploymentName(pipelineId, "lq3x9k2"); pipelineIdFromDeploymentName(name) != pipelineId {
+		t.Errorf("versioned deployment name %s: expected %s, got %s", name, pipelineId, pipelineIdFromDeploymentName(name))
+	}
+	if name := deploymentName(pipelineId, ""); name != names[1] {
+		t.Errorf("unversioned deployment name: expected %s, got %s", names[1], name)
+	}
+	for _, name := range []string{"analytics-flow-engine", "operator-", "operator-abc", "pipelin-" + pipelineId} {
+		if id := pipelineIdFromVolumeName(name); id != "" {
+			t.Errorf("volume name %s: expected no pipeline id, got %s", name, id)
+		}
+		if id := pipelineIdFromDeploymentName(name); id != "" {
+			t.Errorf("deployment name %s: expected no pipeline id, got %s", name, id)
+		}
+	}
+}
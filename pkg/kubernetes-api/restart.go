@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RestartOperators does a rollout restart of every deployment of the pipeline,
+// the pods are replaced one by one with the same configuration.
+func (k *Kubernetes) RestartOperators(pipelineId string, _ []pipe_lib.Operator) error {
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		return k8s_errors.NewNotFound(appsv1.Resource("deployments"), deploymentName(pipelineId, ""))
+	}
+	return k.restartDeployments(deployments)
+}
+
+func (k *Kubernetes) restartDeployments(deployments []appsv1.Deployment) error {
+	patch, err := restartPatch(time.Now())
+	if err != nil {
+		return err
+	}
+	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+	for _, deployment := range deployments {
+		if _, err = deploymentsClient.Patch(context.TODO(), deployment.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func restartPatch(now time.Time) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{AnnotationRestartedAt: now.Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+}
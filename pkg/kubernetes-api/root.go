@@ -0,0 +1,135 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	apiv1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+// rootSpecKey is the key of the pipeline spec in the data of the root object.
+const rootSpecKey = "pipeline"
+
+// A deleted root is kept until the cluster deleted all objects owned by it, which is awaited at most rootDeletionTimeout.
+const (
+	rootDeletionTimeout      = 2 * time.Minute
+	rootDeletionPollInterval = 2 * time.Second
+)
+
+// rootSpec is the spec of the cloud operators of a pipeline, stored in its root object.
+type rootSpec struct {
+	Operators []pipe_lib.Operator `json:"operators"`
+	Config    lib.PipelineConfig  `json:"config"`
+}
+
+// rootName returns the name of the root object of a pipeline, pipeline-<pipelineId>.
+func rootName(pipelineId string) string {
+	return deploymentName(pipelineId, "")
+}
+
+// makeRoot builds the config map all other objects of a pipeline are owned by, so that deleting it
+// deletes the whole pipeline. It is shared by all versions of the pipeline.
+func makeRoot(pipelineId string, labels map[string]string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) *apiv1.ConfigMap {
+	labels = maps.Clone(labels)
+	delete(labels, LabelPipelineVersion)
+	spec, _ := json.Marshal(rootSpec{Operators: inputs, Config: pipeConfig})
+	return &apiv1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiv1.SchemeGroupVersion.String(), Kind: lib.ResourceKindConfigMap},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   rootName(pipelineId),
+			Labels: labels,
+		},
+		Data: map[string]string{rootSpecKey: string(spec)},
+	}
+}
+
+// setOwner makes the applied root the owner of all other objects of the pipeline.
+func (r *pipelineResources) setOwner(root *apiv1.ConfigMap) {
+	owner := []metav1.OwnerReference{{
+		APIVersion:         apiv1.SchemeGroupVersion.String(),
+		Kind:               lib.ResourceKindConfigMap,
+		Name:               root.Name,
+		UID:                root.UID,
+		BlockOwnerDeletion: ptr.To(true),
+	}}
+	for _, pvc := range r.pvcs {
+		pvc.OwnerReferences = owner
+	}
+	for i, deployment := range r.deployments {
+		deployment.OwnerReferences = owner
+		if r.vpas[i] != nil {
+			r.vpas[i].OwnerReferences = owner
+		}
+		if r.hpas[i] != nil {
+			r.hpas[i].OwnerReferences = owner
+		}
+	}
+}
+
+// ownedByRoot returns true if the object is owned by the root object of a pipeline and
+// thus removed by the Kubernetes garbage collector together with it.
+func ownedByRoot(object metav1.Object) bool {
+	for _, owner := range object.GetOwnerReferences() {
+		if owner.Kind == lib.ResourceKindConfigMap && pipelineIdFromDeploymentName(owner.Name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteRoot deletes the root object of a pipeline, the cluster then deletes all objects owned by it.
+// It waits until the root is gone, so that the pipeline can be applied again right away.
+// It returns false if the pipeline has no root object, because it was created before they were introduced.
+func (k *Kubernetes) deleteRoot(pipelineId string) (deleted bool, err error) {
+	deletePolicy := metav1.DeletePropagationForeground
+	name := rootName(pipelineId)
+	configMaps := k.clientset.CoreV1().ConfigMaps(k.r2cfg.NamespaceId)
+	err = configMaps.Delete(context.TODO(), name, metav1.DeleteOptions{
+		PropagationPolicy: &deletePolicy,
+	})
+	if k8s_errors.IsNotFound(err) {
+		util.Logger.Debug("root not found: " + name)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("deleting root %s: %w", name, err)
+	}
+	err = wait.PollUntilContextTimeout(context.TODO(), rootDeletionPollInterval, rootDeletionTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if k8s_errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return true, fmt.Errorf("waiting for deletion of root %s: %w", name, err)
+	}
+	util.Logger.Debug(fmt.Sprintf("deleted root %s", name))
+	return true, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKubernetes_makePipelineResourcesRoot(t *testing.T) {
+	k := &Kubernetes{r2cfg: &config.Rancher2Config{}, vpaCfg: config.VPAConfig{Enabled: true, UpdateMode: "Recreate"}, vpaAvailable: true}
+	ops := []pipe.Operator{{Id: "11111111-op", OperatorId: "a", PersistData: true}}
+	resources := k.makePipelineResources(testPipeId, "v2", ops, lib.PipelineConfig{FlowId: "flow", UserId: "user"})
+
+	root := resources.root
+	if root.Name != deploymentName(testPipeId, "") || root.Labels[LabelPipelineId] != testPipeId || root.Labels[LabelFlowId] != "flow" {
+		t.Errorf("unexpected root %s with labels %v", root.Name, root.Labels)
+	}
+	if _, ok := root.Labels[LabelPipelineVersion]; ok {
+		t.Error("expected root to be shared by all versions")
+	}
+	var spec rootSpec
+	if err := json.Unmarshal([]byte(root.Data[rootSpecKey]), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Operators) != 1 || spec.Config.UserId != "user" {
+		t.Errorf("expected pipeline spec in root, got %+v", spec)
+	}
+
+	root.UID = "uid"
+	resources.setOwner(root)
+	objects := []metav1.Object{resources.pvcs[0], resources.deployments[0], resources.vpas[0]}
+	for _, object := range objects {
+		if owners := object.GetOwnerReferences(); len(owners) != 1 || owners[0].UID != "uid" || !ownedByRoot(object) {
+			t.Errorf("expected %s to be owned by root, got %+v", object.GetName(), owners)
+		}
+	}
+	if ownedByRoot(&metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "pipeline-pid"}}}) {
+		t.Error("expected only config maps to be roots")
+	}
+}
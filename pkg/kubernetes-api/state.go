@@ -0,0 +1,142 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	apiv1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// statePrefix is the name prefix of the config maps holding the state the engine keeps for each pipeline.
+// They are not owned by the root object, which is deleted and applied again whenever the operators are recreated.
+const statePrefix = deploymentPrefix + "state-"
+
+// stateName returns the name of the state object of a pipeline, pipeline-state-<pipelineId>.
+func stateName(pipelineId string) string {
+	return statePrefix + pipelineId
+}
+
+// StateStore keeps one kind of pipeline state, e.g. the paused pipelines, under the key name in the state object
+// of each pipeline. Every kind is applied by its own field manager, so that writing one kind never overwrites another.
+type StateStore struct {
+	configMaps corev1.ConfigMapInterface
+	name       string
+}
+
+// StateStore returns the store of the pipeline state name, keyed by pipeline id.
+func (k *Kubernetes) StateStore(name string) store.Store[json.RawMessage] {
+	return newStateStore(k.clientset.CoreV1().ConfigMaps(k.r2cfg.NamespaceId), name)
+}
+
+func newStateStore(configMaps corev1.ConfigMapInterface, name string) *StateStore {
+	return &StateStore{configMaps: configMaps, name: name}
+}
+
+func (s *StateStore) Put(pipelineId string, value json.RawMessage) error {
+	return s.apply(pipelineId, map[string]string{s.name: string(value)})
+}
+
+func (s *StateStore) Get(pipelineId string) (json.RawMessage, error) {
+	state, err := s.configMaps.Get(context.TODO(), stateName(pipelineId), metav1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, ok := state.Data[s.name]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return json.RawMessage(value), nil
+}
+
+// Delete removes the value of the pipeline, the state object is deleted once it holds no values anymore.
+func (s *StateStore) Delete(pipelineId string) error {
+	err := s.apply(pipelineId, nil)
+	if err != nil {
+		return err
+	}
+	state, err := s.configMaps.Get(context.TODO(), stateName(pipelineId), metav1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil || len(state.Data) > 0 {
+		return err
+	}
+	// the precondition keeps a value another instance stored meanwhile
+	err = s.configMaps.Delete(context.TODO(), state.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &state.ResourceVersion},
+	})
+	if k8s_errors.IsNotFound(err) || k8s_errors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// List returns the values of all pipelines by pipeline id.
+func (s *StateStore) List() (map[string]json.RawMessage, error) {
+	states, err := s.configMaps.List(context.TODO(), metav1.ListOptions{LabelSelector: LabelPipelineId})
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]json.RawMessage)
+	for _, state := range states.Items {
+		pipelineId := state.Labels[LabelPipelineId]
+		if state.Name != stateName(pipelineId) {
+			continue
+		}
+		if value, ok := state.Data[s.name]; ok {
+			values[pipelineId] = json.RawMessage(value)
+		}
+	}
+	return values, nil
+}
+
+// apply sets the values of the store in the state object of the pipeline, values of the store missing in data are removed.
+func (s *StateStore) apply(pipelineId string, data map[string]string) error {
+	state := &apiv1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiv1.SchemeGroupVersion.String(), Kind: lib.ResourceKindConfigMap},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   stateName(pipelineId),
+			Labels: map[string]string{LabelPipelineId: pipelineId},
+		},
+		Data: data,
+	}
+	patch, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	_, err = s.configMaps.Patch(context.TODO(), state.Name, types.ApplyPatchType, patch, metav1.PatchOptions{
+		FieldManager: FieldManager + "-" + s.name,
+		Force:        ptr.To(true),
+	})
+	if err != nil {
+		return fmt.Errorf("applying %s of %s: %w", s.name, state.Name, err)
+	}
+	return nil
+}
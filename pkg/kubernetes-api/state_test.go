@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubernetes_StateStore(t *testing.T) {
+	clientset := fake.NewClientset()
+	paused := newStateStore(clientset.CoreV1().ConfigMaps("ns"), "paused")
+	modes := newStateStore(clientset.CoreV1().ConfigMaps("ns"), "deployment-modes")
+
+	if _, err := paused.Get(testPipeId); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if err := paused.Put(testPipeId, json.RawMessage(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := modes.Put(testPipeId, json.RawMessage(`"pipeline"`)); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := paused.Get(testPipeId); err != nil || string(value) != `{"a":1}` {
+		t.Errorf("expected value to be kept by the other store, got %s, %v", value, err)
+	}
+	if values, err := modes.List(); err != nil || len(values) != 1 || string(values[testPipeId]) != `"pipeline"` {
+		t.Errorf("unexpected values %v, %v", values, err)
+	}
+
+	if err := paused.Delete(testPipeId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := paused.Get(testPipeId); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected deleted value, got %v", err)
+	}
+	if _, err := modes.Get(testPipeId); err != nil {
+		t.Errorf("expected value of other store to be kept, got %v", err)
+	}
+	if err := modes.Delete(testPipeId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps("ns").Get(context.TODO(), stateName(testPipeId), metav1.GetOptions{}); err == nil {
+		t.Error("expected empty state object to be deleted")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const maxStatusEvents = 10
+
+// AddDeploymentStatus adds the replicas and conditions of a deployment to status.
+// The message is set from the first condition which is not met, if no message is set yet.
+func AddDeploymentStatus(status *lib.PipelineStatus, deployment appsv1.Deployment) {
+	if deployment.Spec.Replicas != nil {
+		status.DesiredReplicas += *deployment.Spec.Replicas
+	} else {
+		status.DesiredReplicas++
+	}
+	status.ReadyReplicas += deployment.Status.ReadyReplicas
+	for _, condition := range deployment.Status.Conditions {
+		transition := condition.LastTransitionTime.UTC()
+		status.Conditions = append(status.Conditions, lib.StatusCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: transition,
+		})
+		if status.LastTransitionTime == nil || transition.After(*status.LastTransitionTime) {
+			status.LastTransitionTime = &transition
+		}
+		if status.Message == "" && condition.Status == apiv1.ConditionFalse {
+			status.Message = condition.Message
+		}
+	}
+}
+
+// WarningEvents returns the most recent warning events of the deployments of the pipeline and of their replica sets and pods,
+// newest first. Replica sets and pods are named after their deployment, so every object prefixed with the name of a deployment
+// of all operators or of a single operator belongs to it.
+func WarningEvents(events []apiv1.Event, pipelineId string) []lib.StatusEvent {
+	prefixes := []string{deploymentName(pipelineId, ""), volumePrefix + pipelineId + "-"}
+	var result []lib.StatusEvent
+	for _, event := range events {
+		if event.Type != apiv1.EventTypeWarning || !slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(event.InvolvedObject.Name, prefix)
+		}) {
+			continue
+		}
+		count := event.Count
+		if event.Series != nil {
+			count = event.Series.Count
+		}
+		result = append(result, lib.StatusEvent{
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Object:   event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Count:    max(count, 1),
+			LastSeen: eventTime(event),
+		})
+	}
+	slices.SortStableFunc(result, func(a, b lib.StatusEvent) int {
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+	if len(result) > maxStatusEvents {
+		result = result[:maxStatusEvents]
+	}
+	return result
+}
+
+func eventTime(event apiv1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.UTC()
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.UTC()
+	case !event.EventTime.IsZero():
+		return event.EventTime.UTC()
+	}
+	return event.FirstTimestamp.UTC()
+}
+
+// addEvents adds the recent warning events of the pipeline to status. Events can only be selected by the exact name
+// of their object, so all warning events of the namespace are listed once and matched by WarningEvents. As the events
+// only explain the status, failing to list them is logged but does not fail the status request.
+func (k *Kubernetes) addEvents(status *lib.PipelineStatus, pipelineId string) {
+	events, err := k.clientset.CoreV1().Events(k.r2cfg.NamespaceId).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", apiv1.EventTypeWarning).String(),
+	})
+	if err != nil {
+		util.Logger.Warn("cannot list events", "pipeline", pipelineId, "error", err)
+		return
+	}
+	status.Events = WarningEvents(events.Items, pipelineId)
+	if !status.Running && len(status.Events) > 0 {
+		status.Message = status.Events[0].Reason + ": " + status.Events[0].Message
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentStatus(t *testing.T) {
+	earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	replicas := int32(1)
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: deploymentName(testPipeId, "")},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UnavailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: apiv1.ConditionTrue, LastTransitionTime: metav1.NewTime(earlier)},
+				{Type: appsv1.DeploymentAvailable, Status: apiv1.ConditionFalse, Reason: "MinimumReplicasUnavailable", Message: "Deployment does not have minimum availability.", LastTransitionTime: metav1.NewTime(later)},
+			},
+		},
+	}
+	status := deploymentStatus(deployment)
+	if status.Running || !status.Transitioning {
+		t.Errorf("expected transitioning status, got %+v", status)
+	}
+	if status.ReadyReplicas != 0 || status.DesiredReplicas != 1 {
+		t.Errorf("expected 0/1 replicas, got %d/%d", status.ReadyReplicas, status.DesiredReplicas)
+	}
+	if len(status.Conditions) != 2 || status.Message != "Deployment does not have minimum availability." {
+		t.Errorf("unexpected conditions %+v and message %q", status.Conditions, status.Message)
+	}
+	if status.LastTransitionTime == nil || !status.LastTransitionTime.Equal(later) {
+		t.Errorf("expected last transition at %v, got %v", later, status.LastTransitionTime)
+	}
+
+	version := deployment
+	version.Name = deploymentName(testPipeId, "v2")
+	version.Status = appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1}
+	merged := mergeStatus(status, deploymentStatus(version))
+	if !merged.Running || merged.ReadyReplicas != 1 || merged.DesiredReplicas != 2 {
+		t.Errorf("unexpected merged status %+v", merged)
+	}
+}
+
+func TestPipelineStatus(t *testing.T) {
+	operator := func(name, version string, available, unavailable int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{LabelPipelineId: testPipeId, LabelPipelineVersion: version}},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: available, ReadyReplicas: available, UnavailableReplicas: unavailable},
+		}
+	}
+
+	status := pipelineStatus([]appsv1.Deployment{operator("operator-b", "", 0, 1), operator("operator-a", "", 1, 0)})
+	if status.Running || !status.Transitioning || status.ReadyReplicas != 1 || status.DesiredReplicas != 2 {
+		t.Errorf("expected pipeline with an unavailable operator to be transitioning, got %+v", status)
+	}
+
+	status = pipelineStatus([]appsv1.Deployment{operator("operator-a", "", 1, 0), operator("operator-b", "", 1, 0)})
+	if !status.Running || status.Transitioning {
+		t.Errorf("expected running pipeline, got %+v", status)
+	}
+
+	status = pipelineStatus([]appsv1.Deployment{
+		operator("operator-a", "", 1, 0), operator("operator-b", "", 1, 0),
+		operator("operator-a--v2", "v2", 0, 1), operator("operator-b--v2", "v2", 1, 0),
+	})
+	if !status.Running || !status.Transitioning || status.DesiredReplicas != 4 {
+		t.Errorf("expected running pipeline while the new version starts, got %+v", status)
+	}
+}
+
+func TestWarningEvents(t *testing.T) {
+	old := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := old.Add(time.Minute)
+	events := []apiv1.Event{
+		{
+			Type:           apiv1.EventTypeWarning,
+			Reason:         "FailedScheduling",
+			Message:        "0/3 nodes are available",
+			InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: deploymentName(testPipeId, "") + "-5d8f7c-abcde"},
+			Count:          2,
+			LastTimestamp:  metav1.NewTime(old),
+		},
+		{
+			Type:           apiv1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off pulling image",
+			InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: deploymentName(testPipeId, "v2") + "-6c9d8b-fghij"},
+			Series:         &apiv1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(recent)},
+		},
+		{
+			Type:           apiv1.EventTypeNormal,
+			Reason:         "Scheduled",
+			InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: deploymentName(testPipeId, "") + "-5d8f7c-abcde"},
+		},
+		{
+			Type:           apiv1.EventTypeWarning,
+			Reason:         "BackOff",
+			InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: deploymentName("other", "") + "-5d8f7c-abcde"},
+		},
+	}
+	result := WarningEvents(events, testPipeId)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 warning events, got %+v", result)
+	}
+	if result[0].Reason != "BackOff" || result[0].Count != 5 || !result[0].LastSeen.Equal(recent) {
+		t.Errorf("expected the most recent event first, got %+v", result[0])
+	}
+	if result[1].Reason != "FailedScheduling" || result[1].Object != "Pod/"+deploymentName(testPipeId, "")+"-5d8f7c-abcde" {
+		t.Errorf("unexpected event %+v", result[1])
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateOperatorsVersion creates the cloud operators of a pipeline as deployment pipeline-<pipelineId>--<version>
+// next to the deployments already running for the pipeline.
+func (k *Kubernetes) CreateOperatorsVersion(pipelineId, version string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) error {
+	return k.createOperators(pipelineId, version, inputs, pipeConfig)
+}
+
+// GetPipelineVersionStatus returns the combined status of the deployments of a single version of the pipeline.
+func (k *Kubernetes) GetPipelineVersionStatus(pipelineId, version string) (pipeStatus lib.PipelineStatus, err error) {
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return
+	}
+	deployments = slices.DeleteFunc(deployments, func(deployment appsv1.Deployment) bool {
+		return deployment.Labels[LabelPipelineVersion] != version
+	})
+	if len(deployments) == 0 {
+		err = k8s_errors.NewNotFound(appsv1.Resource("deployments"), deploymentName(pipelineId, version))
+		return
+	}
+	return pipelineStatus(deployments), nil
+}
+
+// GetPipelineVersions lists the versions of the deployments of the pipeline, the deployments
+// which are not versioned have the empty version. In the operator deployment mode a version has several deployments.
+func (k *Kubernetes) GetPipelineVersions(pipelineId string) (versions []string, err error) {
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return
+	}
+	for _, deployment := range deployments {
+		if version := deployment.Labels[LabelPipelineVersion]; !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	return
+}
+
+// pipelineDeployments returns the labeled deployments of the pipeline
+// and the unversioned one, which might have been created without labels.
+func (k *Kubernetes) pipelineDeployments(pipelineId string) (deployments []appsv1.Deployment, err error) {
+	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+	list, err := deploymentsClient.List(context.TODO(), metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{LabelPipelineId: pipelineId}}),
+	})
+	if err != nil {
+		return
+	}
+	deployments = list.Items
+	name := deploymentName(pipelineId, "")
+	if slices.ContainsFunc(deployments, func(d appsv1.Deployment) bool { return d.Name == name }) {
+		return
+	}
+	deployment, err := deploymentsClient.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			err = nil
+		}
+		return
+	}
+	deployments = append([]appsv1.Deployment{*deployment}, deployments...)
+	return
+}
+
+func deploymentName(pipelineId, version string) string {
+	name := getOperatorName(pipelineId, pipe_lib.Operator{Id: DummyOperatorId})[1]
+	if version != "" {
+		name += versionSeparator + version
+	}
+	return name
+}
+
+// operatorDeploymentName returns the name of the deployment of a single operator in the operator deployment mode.
+func operatorDeploymentName(pipelineId string, operator pipe_lib.Operator, version string) string {
+	name := getOperatorName(pipelineId, operator)[0]
+	if version != "" {
+		name += versionSeparator + version
+	}
+	return name
+}
+
+// deploymentPipelineId returns the pipeline a deployment belongs to, by its label or its name.
+func deploymentPipelineId(deployment appsv1.Deployment) string {
+	if pipelineId := deployment.Labels[LabelPipelineId]; pipelineId != "" {
+		return pipelineId
+	}
+	return pipelineIdFromDeploymentName(deployment.Name)
+}
+
+func deploymentStatus(deployment appsv1.Deployment) lib.PipelineStatus {
+	status := lib.PipelineStatus{
+		Running:       deployment.Status.AvailableReplicas > 0 && deployment.Status.UnavailableReplicas == 0,
+		Transitioning: deployment.Status.UnavailableReplicas > 0,
+		Message:       "",
+		Name:          deployment.Name,
+	}
+	AddDeploymentStatus(&status, deployment)
+	return status
+}
+
+// pipelineStatus combines the status of the deployments of a pipeline, ordered by name to report them consistently.
+// The deployments of one version, a single one or one per operator, are joined with joinStatus, versions are merged with mergeStatus.
+func pipelineStatus(deployments []appsv1.Deployment) lib.PipelineStatus {
+	slices.SortFunc(deployments, func(a, b appsv1.Deployment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	var versions []string
+	statuses := map[string]lib.PipelineStatus{}
+	for _, deployment := range deployments {
+		version := deployment.Labels[LabelPipelineVersion]
+		status, ok := statuses[version]
+		if !ok {
+			versions = append(versions, version)
+			statuses[version] = deploymentStatus(deployment)
+			continue
+		}
+		statuses[version] = joinStatus(status, deploymentStatus(deployment))
+	}
+	var status lib.PipelineStatus
+	for i, version := range versions {
+		if i == 0 {
+			status = statuses[version]
+			continue
+		}
+		status = mergeStatus(status, statuses[version])
+	}
+	status.Name = ""
+	return status
+}
+
+// joinStatus combines the status of two deployments of the same version, which run different operators of a pipeline.
+// The pipeline is only running if both are and transitioning if one is. Replicas and conditions are added up.
+func joinStatus(a, b lib.PipelineStatus) lib.PipelineStatus {
+	running, transitioning := a.Running && b.Running, a.Transitioning || b.Transitioning
+	a = mergeStatus(a, b)
+	a.Running, a.Transitioning = running, transitioning
+	return a
+}
+
+// mergeStatus combines the status of two versions of a pipeline. The pipeline is running if one version is,
+// while more than one version exists it is transitioning. Replicas and conditions of both versions are added up.
+func mergeStatus(a, b lib.PipelineStatus) lib.PipelineStatus {
+	a.Running = a.Running || b.Running
+	a.Transitioning = true
+	a.ReadyReplicas += b.ReadyReplicas
+	a.DesiredReplicas += b.DesiredReplicas
+	a.Conditions = append(a.Conditions, b.Conditions...)
+	if a.LastTransitionTime == nil || (b.LastTransitionTime != nil && b.LastTransitionTime.After(*a.LastTransitionTime)) {
+		a.LastTransitionTime = b.LastTransitionTime
+	}
+	if a.Message == "" {
+		a.Message = b.Message
+	}
+	return a
+}
+
+// PauseOperators scales every deployment version of the pipeline to zero, volumes and autoscalers are kept.
+// A horizontal autoscaler does not scale a deployment with zero replicas.
+func (k *Kubernetes) PauseOperators(pipelineId string, _ []pipe_lib.Operator) error {
+	_, err := k.scaleOperators(pipelineId, func(appsv1.Deployment) int32 { return 0 })
+	return err
+}
+
+// ResumeOperators scales the deployments of the pipeline up to the replicas they were created with again,
+// or creates them if they were removed in the meantime.
+func (k *Kubernetes) ResumeOperators(pipelineId string, inputs []pipe_lib.Operator, pipeConfig lib.PipelineConfig) error {
+	scaled, err := k.scaleOperators(pipelineId, initialReplicas)
+	if err != nil || scaled > 0 {
+		return err
+	}
+	util.Logger.Warn("no deployment found for paused pipeline, creating it", "pipeline", pipelineId)
+	return k.CreateOperators(pipelineId, inputs, pipeConfig)
+}
+
+// initialReplicas returns the replicas a deployment was created with, one for deployments created before they were annotated.
+func initialReplicas(deployment appsv1.Deployment) int32 {
+	replicas, err := strconv.ParseInt(deployment.Annotations[AnnotationReplicas], 10, 32)
+	if err != nil || replicas < 1 {
+		return 1
+	}
+	return int32(replicas)
+}
+
+func (k *Kubernetes) scaleOperators(pipelineId string, replicas func(appsv1.Deployment) int32) (scaled int, err error) {
+	deploymentsClient := k.clientset.AppsV1().Deployments(k.r2cfg.NamespaceId)
+	deployments, err := k.pipelineDeployments(pipelineId)
+	if err != nil {
+		return
+	}
+	for _, deployment := range deployments {
+		scale, err := deploymentsClient.GetScale(context.TODO(), deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return scaled, err
+		}
+		scale.Spec.Replicas = replicas(deployment)
+		util.Logger.Debug(fmt.Sprintf("scaling deployment %s to %d", deployment.Name, scale.Spec.Replicas))
+		if _, err = deploymentsClient.UpdateScale(context.TODO(), deployment.Name, scale, metav1.UpdateOptions{}); err != nil {
+			return scaled, err
+		}
+		scaled++
+	}
+	return
+}
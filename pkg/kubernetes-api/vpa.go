@@ -0,0 +1,136 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	pipe_lib "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/client-go/discovery"
+)
+
+var vpaUpdateModes = []v1.UpdateMode{v1.UpdateModeOff, v1.UpdateModeInitial, v1.UpdateModeRecreate, v1.UpdateModeInPlaceOrRecreate}
+
+// ValidateVPAConfig returns an error if the update mode is unknown or the bounds of a profile are no valid quantities.
+func ValidateVPAConfig(cfg config.VPAConfig) error {
+	if !slices.Contains(vpaUpdateModes, v1.UpdateMode(cfg.UpdateMode)) {
+		return fmt.Errorf("unknown vpa update mode %q", cfg.UpdateMode)
+	}
+	for profile, bounds := range cfg.Profiles {
+		if _, ok := lib.ResourceProfiles[profile]; !ok {
+			return fmt.Errorf("unknown resource profile %q in vpa profiles", profile)
+		}
+		for _, quantity := range []string{bounds.MinCpu, bounds.MinMemory, bounds.MaxCpu, bounds.MaxMemory} {
+			if quantity == "" {
+				continue
+			}
+			if _, err := resource.ParseQuantity(quantity); err != nil {
+				return fmt.Errorf("invalid vpa bound %q of profile %s: %w", quantity, profile, err)
+			}
+		}
+	}
+	return nil
+}
+
+// detectVPA returns true if the cluster serves the custom resources of the vertical pod autoscaler.
+func detectVPA(client discovery.DiscoveryInterface) (bool, error) {
+	resources, err := client.ServerResourcesForGroupVersion(v1.SchemeGroupVersion.String())
+	if k8s_errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(resources.APIResources, func(r metav1.APIResource) bool {
+		return r.Name == "verticalpodautoscalers"
+	}), nil
+}
+
+func (k *Kubernetes) vpaEnabled() bool {
+	return k.vpaCfg.Enabled && k.vpaAvailable
+}
+
+// makeVPA builds the vertical pod autoscaler of the deployment name running operators, or returns nil if it is disabled.
+// Every container is bounded by the configuration of the resource profile of its operator. If the deployment is scaled
+// horizontally on its CPU utilization, the vertical autoscaler only controls the memory.
+func (k *Kubernetes) makeVPA(name string, labels map[string]string, operators []pipe_lib.Operator, pipeConfig lib.PipelineConfig, scaling lib.OperatorScaling) *v1.VerticalPodAutoscaler {
+	if !k.vpaEnabled() {
+		return nil
+	}
+	updateMode := v1.UpdateMode(k.vpaCfg.UpdateMode)
+	var controlledResources *[]apiv1.ResourceName
+	if scaling.Autoscaled() && scaling.TargetCpuUtilization > 0 {
+		controlledResources = &[]apiv1.ResourceName{apiv1.ResourceMemory}
+	}
+	var policies []v1.ContainerResourcePolicy
+	for _, operator := range operators {
+		minAllowed, maxAllowed := k.vpaBounds(pipeConfig.OperatorResources(operator.Id).Profile, pipeConfig)
+		policies = append(policies, v1.ContainerResourcePolicy{
+			ContainerName:       ContainerName(operator),
+			MinAllowed:          minAllowed,
+			MaxAllowed:          maxAllowed,
+			ControlledResources: controlledResources,
+		})
+	}
+	return &v1.VerticalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: lib.ResourceKindVerticalPodAutoscaler},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name + vpaSuffix,
+			Labels: labels,
+		},
+		Spec: v1.VerticalPodAutoscalerSpec{
+			TargetRef:      &autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+			UpdatePolicy:   &v1.PodUpdatePolicy{UpdateMode: &updateMode},
+			ResourcePolicy: &v1.PodResourcePolicy{ContainerPolicies: policies},
+		},
+	}
+}
+
+// vpaBounds returns the resources the autoscaler may assign to an operator of profile, they are validated on startup.
+func (k *Kubernetes) vpaBounds(profile string, pipeConfig lib.PipelineConfig) (minAllowed, maxAllowed apiv1.ResourceList) {
+	bounds := k.vpaCfg.Profiles[profile]
+	maxCpu, maxMemory := pipeConfig.MaxAllowedResources()
+	if bounds.MaxCpu != "" {
+		maxCpu = bounds.MaxCpu
+	}
+	if bounds.MaxMemory != "" {
+		maxMemory = bounds.MaxMemory
+	}
+	maxAllowed = apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse(maxCpu),
+		apiv1.ResourceMemory: resource.MustParse(maxMemory),
+	}
+	if bounds.MinCpu != "" || bounds.MinMemory != "" {
+		minAllowed = apiv1.ResourceList{}
+	}
+	if bounds.MinCpu != "" {
+		minAllowed[apiv1.ResourceCPU] = resource.MustParse(bounds.MinCpu)
+	}
+	if bounds.MinMemory != "" {
+		minAllowed[apiv1.ResourceMemory] = resource.MustParse(bounds.MinMemory)
+	}
+	return
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateVPAConfig(t *testing.T) {
+	valid := config.VPAConfig{UpdateMode: "InPlaceOrRecreate", Profiles: map[string]config.VPAProfileConfig{
+		lib.ResourceProfileSmall: {MinMemory: "64Mi", MaxCpu: "500m"},
+	}}
+	if err := ValidateVPAConfig(valid); err != nil {
+		t.Error(err)
+	}
+	invalid := []config.VPAConfig{
+		{UpdateMode: "Auto"},
+		{UpdateMode: "Off", Profiles: map[string]config.VPAProfileConfig{"huge": {}}},
+		{UpdateMode: "Off", Profiles: map[string]config.VPAProfileConfig{lib.ResourceProfileSmall: {MaxMemory: "lots"}}},
+	}
+	for _, cfg := range invalid {
+		if ValidateVPAConfig(cfg) == nil {
+			t.Errorf("expected error for %+v", cfg)
+		}
+	}
+}
+
+func TestDetectVPA(t *testing.T) {
+	clientset := fake.NewClientset()
+	if available, err := detectVPA(clientset.Discovery()); err != nil || available {
+		t.Errorf("expected vpa to be unavailable without its resources, got %v, %v", available, err)
+	}
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: v1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "verticalpodautoscalers"}, {Name: "verticalpodautoscalercheckpoints"}},
+	}}
+	if available, err := detectVPA(clientset.Discovery()); err != nil || !available {
+		t.Errorf("expected vpa to be available, got %v, %v", available, err)
+	}
+}
+
+func TestKubernetes_makeVPA(t *testing.T) {
+	k := &Kubernetes{vpaCfg: config.VPAConfig{Enabled: true, UpdateMode: "Initial", Profiles: map[string]config.VPAProfileConfig{
+		lib.ResourceProfileLarge: {MinMemory: "256Mi", MaxMemory: "8Gi"},
+	}}}
+	ops := []pipe.Operator{{Id: "small", OperatorId: "a"}, {Id: "large", OperatorId: "b"}}
+	pipeConfig := lib.PipelineConfig{
+		Resources: map[string]lib.OperatorResources{
+			"small": lib.ResourceProfiles[lib.ResourceProfileSmall],
+			"large": lib.ResourceProfiles[lib.ResourceProfileLarge],
+		},
+		MaxResources: lib.OperatorResources{CpuLimit: "1000m", MemoryLimit: "4000Mi"},
+	}
+
+	if k.makeVPA("pipeline-pid", nil, ops, pipeConfig, lib.OperatorScaling{}) != nil {
+		t.Error("expected no vpa if the cluster does not provide it")
+	}
+
+	k.vpaAvailable = true
+	vpa := k.makeVPA("pipeline-pid", nil, ops, pipeConfig, lib.OperatorScaling{})
+	if *vpa.Spec.UpdatePolicy.UpdateMode != v1.UpdateModeInitial {
+		t.Errorf("expected configured update mode, got %s", *vpa.Spec.UpdatePolicy.UpdateMode)
+	}
+	policies := vpa.Spec.ResourcePolicy.ContainerPolicies
+	if len(policies) != 2 || policies[0].ContainerName != ContainerName(ops[0]) {
+		t.Fatalf("expected a policy per container, got %+v", policies)
+	}
+	if policies[0].MinAllowed != nil || !policies[0].MaxAllowed.Memory().Equal(resource.MustParse("4000Mi")) {
+		t.Errorf("expected global maximum for profile without bounds, got %+v", policies[0])
+	}
+	if !policies[1].MinAllowed.Memory().Equal(resource.MustParse("256Mi")) || !policies[1].MaxAllowed.Memory().Equal(resource.MustParse("8Gi")) || !policies[1].MaxAllowed.Cpu().Equal(resource.MustParse("1000m")) {
+		t.Errorf("expected bounds of the profile, got %+v", policies[1])
+	}
+
+	k.vpaCfg.Enabled = false
+	if k.makeVPA("pipeline-pid", nil, ops, pipeConfig, lib.OperatorScaling{}) != nil {
+		t.Error("expected no vpa if it is disabled")
+	}
+}
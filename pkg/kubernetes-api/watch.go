@@ -0,0 +1,69 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes_api
+
+import (
+	"context"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WatchPipelinesStatus calls onChange with the combined status of the deployments of a pipeline whenever one of them
+// is added, changed or deleted in the deployment cache. Only deployments labeled with a pipeline ID are cached,
+// a pipeline without deployments is reported as not running.
+func (k *Kubernetes) WatchPipelinesStatus(ctx context.Context, onChange func(pipelineId string, status lib.PipelineStatus)) error {
+	notify := func(obj any) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return
+		}
+		pipelineId := deployment.Labels[LabelPipelineId]
+		list, err := k.deployments.lister.List(labels.SelectorFromSet(labels.Set{LabelPipelineId: pipelineId}))
+		if err != nil {
+			util.Logger.Error("cannot list cached deployments", "pipeline", pipelineId, "error", err)
+			return
+		}
+		onChange(pipelineId, combinedStatus(list))
+	}
+	_, err := k.deployments.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, obj any) { notify(obj) },
+		DeleteFunc: notify,
+	})
+	if err != nil {
+		return err
+	}
+	// the cache is usually started already, otherwise it is started here
+	k.deployments.factory.Start(ctx.Done())
+	return nil
+}
+
+// combinedStatus combines the status of all deployments of a pipeline.
+func combinedStatus(deployments []*appsv1.Deployment) lib.PipelineStatus {
+	values := make([]appsv1.Deployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		values = append(values, *deployment)
+	}
+	return pipelineStatus(values)
+}
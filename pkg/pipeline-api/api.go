@@ -89,6 +89,10 @@ func (p *PipelineApi) GetPipeline(id string, userId string, authorization string
 		err = lib.NewForbiddenError(lib.NewNotFoundError(fmt.Errorf("could not access pipeline %s", id)))
 		return
 	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		err = lib.NewUnauthorizedError(fmt.Errorf("token not accepted for pipeline %s", id))
+		return
+	}
 	if resp.StatusCode != 200 {
 		return pipe, errors.New("pipeline API - could not get pipeline from pipeline registry: " + strconv.Itoa(resp.StatusCode) + " " + body)
 	}
@@ -116,6 +120,10 @@ func (p *PipelineApi) GetPipelines(userId string, authorization string) (pipelin
 		err = lib.NewForbiddenError(lib.NewNotFoundError(fmt.Errorf("could not access pipelines")))
 		return
 	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		err = lib.NewUnauthorizedError(fmt.Errorf("token not accepted for pipelines"))
+		return
+	}
 	if resp.StatusCode != 200 {
 		err = errors.New("pipeline API - could not get pipelines from pipeline registry: " + strconv.Itoa(resp.StatusCode) + " " + body)
 		return
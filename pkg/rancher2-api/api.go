@@ -20,18 +20,22 @@ import (
 	"crypto/tls"
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	kubernetes_api "github.com/SENERGY-Platform/analytics-flow-engine/pkg/kubernetes-api"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
 	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
 
 	"encoding/json"
 
 	"github.com/parnurzeal/gorequest"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 type Rancher2 struct {
@@ -73,6 +77,13 @@ func (r *Rancher2) GetPipelineStatus(pipelineId string) (status lib.PipelineStat
 		Transitioning: deployment.Metadata.State.Transitioning,
 		Message:       deployment.Metadata.State.Message,
 	}
+	kubernetes_api.AddDeploymentStatus(&status, appsv1.Deployment{Spec: deployment.Spec, Status: deployment.Status})
+	var events EventsResponse
+	if e := r.getKubeResource("events/analytics-pipelines?fieldSelector="+url.QueryEscape("type="+apiv1.EventTypeWarning), &events); e != nil {
+		util.Logger.Warn("rancher2 API - cannot get events", "pipeline", pipelineId, "error", e)
+		return
+	}
+	status.Events = kubernetes_api.WarningEvents(events.Data, pipelineId)
 	return
 }
 
@@ -96,17 +107,77 @@ func (r *Rancher2) GetPipelinesStatus() (status []lib.PipelineStatus, err error)
 		return
 	}
 	for _, deployment := range deployments.Data {
-		status = append(status, lib.PipelineStatus{
+		pipeStatus := lib.PipelineStatus{
 			Running:       deployment.Metadata.State.Error == false && deployment.Metadata.State.Transitioning == false,
 			Transitioning: deployment.Metadata.State.Transitioning,
 			Message:       deployment.Metadata.State.Message,
 			Name:          deployment.Metadata.Name,
-		})
+		}
+		kubernetes_api.AddDeploymentStatus(&pipeStatus, appsv1.Deployment{Spec: deployment.Spec, Status: deployment.Status})
+		status = append(status, pipeStatus)
 	}
 	return
 }
 
 func (r *Rancher2) CreateOperators(pipelineId string, inputs []pipe.Operator, pipeConfig lib.PipelineConfig) (err error) {
+	return r.createOperators(pipelineId, inputs, pipeConfig, true)
+}
+
+func (r *Rancher2) createOperators(pipelineId string, inputs []pipe.Operator, pipeConfig lib.PipelineConfig, createVolumes bool) (err error) {
+	workload, autoscaleRequest, volumeClaims := r.makeWorkloadRequests(pipelineId, inputs, pipeConfig)
+	if createVolumes {
+		for _, volumeClaim := range volumeClaims {
+			err = r.createPersistentVolumeClaim(volumeClaim.Name)
+		}
+		time.Sleep(3 * time.Second)
+	}
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e := request.Post(r.url + "projects/" + r.r2cfg.ProjectId + "/workloads").Send(workload).End()
+	if len(e) > 0 {
+		util.Logger.Error("rancher2 API - could not create operators ", "error", e)
+		err = errors.New("rancher2 API -  could not create operators - an error occurred")
+		return
+	}
+	if resp.StatusCode != http.StatusCreated {
+		errBody := ErrorBody{}
+		err = json.Unmarshal([]byte(body), &errBody)
+		if err != nil {
+			return err
+		}
+		if errBody.Code != "AlreadyExists" {
+			err = errors.New("rancher2 API - could not create operators " + errBody.Code)
+		}
+	}
+	if len(e) > 0 {
+		err = errors.New("rancher2 API -  could not create operators - an error occurred")
+	}
+
+	request = gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e = request.Post(r.kubeUrl + "autoscaling.k8s.io.verticalpodautoscalers").
+		Send(autoscaleRequest).End()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
+		err = errors.New("rancher2 API - could not create vpa " + body)
+	}
+	if len(e) > 0 {
+		err = errors.New("rancher2 API -  could not create operator vpa - an error occurred")
+	}
+	return
+}
+
+// PlanOperators returns the requests CreateOperators would send without sending them.
+func (r *Rancher2) PlanOperators(pipelineId string, inputs []pipe.Operator, pipeConfig lib.PipelineConfig) (planned []lib.PlannedResource, err error) {
+	workload, autoscaleRequest, volumeClaims := r.makeWorkloadRequests(pipelineId, inputs, pipeConfig)
+	for _, volumeClaim := range volumeClaims {
+		planned = append(planned, lib.PlannedResource{Kind: lib.ResourceKindPersistentVolumeClaim, Name: volumeClaim.Name, Manifest: volumeClaim})
+	}
+	planned = append(planned,
+		lib.PlannedResource{Kind: lib.ResourceKindDeployment, Name: workload.Name, Manifest: workload},
+		lib.PlannedResource{Kind: lib.ResourceKindVerticalPodAutoscaler, Name: autoscaleRequest.Metadata.Name, Manifest: autoscaleRequest},
+	)
+	return
+}
+
+func (r *Rancher2) makeWorkloadRequests(pipelineId string, inputs []pipe.Operator, pipeConfig lib.PipelineConfig) (workload *WorkloadRequest, autoscaleRequest AutoscalingRequest, volumeClaims []*VolumeClaimRequest) {
 	var containers []Container
 	var volumes []Volume
 	basePort := 8080
@@ -156,7 +227,7 @@ func (r *Rancher2) CreateOperators(pipelineId string, inputs []pipe.Operator, pi
 		container.Env = r2Env
 
 		if operator.PersistData {
-			err = r.createPersistentVolumeClaim(r.getOperatorName(pipelineId, operator)[0])
+			volumeClaims = append(volumeClaims, r.makeVolumeClaimRequest(r.getOperatorName(pipelineId, operator)[0]))
 			vm := VolumeMount{
 				Name:      r.getOperatorName(pipelineId, operator)[0],
 				MountPath: "/opt/data",
@@ -167,22 +238,21 @@ func (r *Rancher2) CreateOperators(pipelineId string, inputs []pipe.Operator, pi
 				PersistentVolumeClaim: PersistentVolumeClaim{PersistentVolumeClaimId: r.getOperatorName(pipelineId, operator)[0]}},
 			)
 		}
+		resources := pipeConfig.OperatorResources(operator.Id)
 		container.Resources = ContainerResources{
 			Requests: map[string]string{
-				"memory": "128Mi",
-				"cpu":    "100m",
+				"memory": resources.MemoryRequest,
+				"cpu":    resources.CpuRequest,
 			},
 			Limits: map[string]string{
-				"memory": "512Mi",
-				"cpu":    "500m",
+				"memory": resources.MemoryLimit,
+				"cpu":    resources.CpuLimit,
 			},
 		}
 		container.Labels = labels
 		containers = append(containers, container)
 	}
-	time.Sleep(3 * time.Second)
-	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
-	reqBody := &WorkloadRequest{
+	workload = &WorkloadRequest{
 		Name:        r.getOperatorName(pipelineId, pipe.Operator{Id: "v3-123456789"})[1],
 		NamespaceId: r.r2cfg.NamespaceId,
 		Volumes:     volumes,
@@ -192,27 +262,8 @@ func (r *Rancher2) CreateOperators(pipelineId string, inputs []pipe.Operator, pi
 		Selector:    Selector{MatchLabels: map[string]string{"pipelineId": pipelineId}},
 	}
 
-	resp, body, e := request.Post(r.url + "projects/" + r.r2cfg.ProjectId + "/workloads").Send(reqBody).End()
-	if len(e) > 0 {
-		util.Logger.Error("rancher2 API - could not create operators ", "error", e)
-		err = errors.New("rancher2 API -  could not create operators - an error occurred")
-		return
-	}
-	if resp.StatusCode != http.StatusCreated {
-		errBody := ErrorBody{}
-		err = json.Unmarshal([]byte(body), &errBody)
-		if err != nil {
-			return err
-		}
-		if errBody.Code != "AlreadyExists" {
-			err = errors.New("rancher2 API - could not create operators " + errBody.Code)
-		}
-	}
-	if len(e) > 0 {
-		err = errors.New("rancher2 API -  could not create operators - an error occurred")
-	}
-
-	autoscaleRequest := AutoscalingRequest{
+	maxCpu, maxMemory := pipeConfig.MaxAllowedResources()
+	autoscaleRequest = AutoscalingRequest{
 		ApiVersion: "autoscaling.k8s.io/v1",
 		Kind:       "VerticalPodAutoscaler",
 		Metadata: AutoscalingRequestMetadata{
@@ -231,23 +282,14 @@ func (r *Rancher2) CreateOperators(pipelineId string, inputs []pipe.Operator, pi
 					{
 						ContainerName: "*",
 						MaxAllowed: MaxAllowed{
-							CPU:    1,
-							Memory: "4000Mi",
+							CPU:    maxCpu,
+							Memory: maxMemory,
 						},
 					},
 				},
 			},
 		},
 	}
-	request = gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
-	resp, body, e = request.Post(r.kubeUrl + "autoscaling.k8s.io.verticalpodautoscalers").
-		Send(autoscaleRequest).End()
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		err = errors.New("rancher2 API - could not create vpa " + body)
-	}
-	if len(e) > 0 {
-		err = errors.New("rancher2 API -  could not create operator vpa - an error occurred")
-	}
 	return
 }
 
@@ -316,6 +358,9 @@ func (r *Rancher2) DeleteOperators(pipelineId string, operators []pipe.Operator)
 		// Delete Volume
 		if operator.PersistData {
 			err = r.deletePersistentVolumeClaim(r.getOperatorName(pipelineId, operator)[0])
+			if err != nil {
+				return
+			}
 		}
 		// Delete AutoscalerCheckpoint
 		autoscalerCheckpointId := r.getOperatorName(pipelineId, operator)[1] + "-vpa-" + operator.OperatorId + "--" + operator.Id
@@ -343,6 +388,41 @@ func (r *Rancher2) DeleteOperators(pipelineId string, operators []pipe.Operator)
 	return
 }
 
+// PauseOperators removes the workload, service and autoscaler of the pipeline, the volumes are kept.
+func (r *Rancher2) PauseOperators(pipelineId string, operators []pipe.Operator) (err error) {
+	var withoutVolumes []pipe.Operator
+	for _, operator := range operators {
+		operator.PersistData = false
+		withoutVolumes = append(withoutVolumes, operator)
+	}
+	return r.DeleteOperators(pipelineId, withoutVolumes)
+}
+
+// ResumeOperators creates the workload of a paused pipeline again, its volumes already exist.
+func (r *Rancher2) ResumeOperators(pipelineId string, operators []pipe.Operator, pipeConfig lib.PipelineConfig) (err error) {
+	return r.createOperators(pipelineId, operators, pipeConfig, false)
+}
+
+// RestartOperators redeploys the workload of the pipeline, which replaces its pods.
+func (r *Rancher2) RestartOperators(pipelineId string, _ []pipe.Operator) (err error) {
+	name := r.getOperatorName(pipelineId, pipe.Operator{Id: "v3-123456789"})[1]
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e := request.Post(r.url + "projects/" + r.r2cfg.ProjectId + "/workloads/deployment:" +
+		r.r2cfg.NamespaceId + ":" + name + "?action=redeploy").End()
+	if len(e) > 0 {
+		err = errors.New("rancher2 API - could not restart operators - " + e[0].Error())
+		return
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	case http.StatusNotFound:
+		err = lib.NewNotFoundError(errors.New("rancher2 API - cannot restart operators, workload " + name + " does not exist"))
+	default:
+		err = errors.New("rancher2 API - could not restart operators - " + strconv.Itoa(resp.StatusCode) + " - " + body)
+	}
+	return
+}
+
 func (r *Rancher2) DeleteOperator(pipelineId string, operator pipe.Operator) (err error) {
 
 	// Delete AutoscalerCheckpoint
@@ -439,15 +519,19 @@ func (r *Rancher2) getOperatorName(pipelineId string, operator pipe.Operator) []
 	return []string{"operator-" + pipelineId + "-" + operator.Id[0:8], "pipeline-" + pipelineId}
 }
 
-func (r *Rancher2) createPersistentVolumeClaim(name string) (err error) {
-	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
-	reqBody := &VolumeClaimRequest{
+func (r *Rancher2) makeVolumeClaimRequest(name string) *VolumeClaimRequest {
+	return &VolumeClaimRequest{
 		Name:           name,
 		NamespaceId:    r.r2cfg.NamespaceId,
 		AccessModes:    []string{"ReadWriteOnce"},
 		Resources:      Resources{Requests: map[string]string{"storage": "50M"}},
 		StorageClassId: *r.r2cfg.StorageDriver,
 	}
+}
+
+func (r *Rancher2) createPersistentVolumeClaim(name string) (err error) {
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	reqBody := r.makeVolumeClaimRequest(name)
 	resp, body, e := request.Post(r.url + "projects/" + r.r2cfg.ProjectId + "/persistentvolumeclaims").Send(reqBody).End()
 	if len(e) > 0 {
 		return errors.New("rancher2 API - could not create PersistentVolumeClaim: an error occurred")
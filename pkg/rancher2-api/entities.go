@@ -16,6 +16,11 @@
 
 package rancher2_api
 
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+)
+
 type WorkloadRequest struct {
 	Name        string            `json:"name,omitempty"`
 	NamespaceId string            `json:"namespaceId,omitempty"`
@@ -125,7 +130,7 @@ type ContainerPolicy struct {
 }
 
 type MaxAllowed struct {
-	CPU    int64  `json:"cpu,omitempty"`
+	CPU    string `json:"cpu,omitempty"`
 	Memory string `json:"memory,omitempty"`
 }
 
@@ -146,7 +151,8 @@ type DeploymentStatus struct {
 type DeploymentMetaData struct {
 	Name              string
 	Namespace         string
-	CreationTimestamp string `json:"creationTimestamp"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels"`
 	State             DeploymentMetaDataState
 }
 
@@ -158,6 +164,30 @@ type DeploymentResponse struct {
 	Id       string
 	APIType  string `json:"type"`
 	Metadata DeploymentMetaData
+	Spec     appsv1.DeploymentSpec   `json:"spec"`
+	Status   appsv1.DeploymentStatus `json:"status"`
+}
+
+type EventsResponse struct {
+	Data []apiv1.Event `json:"data"`
+}
+
+type ResourcesResponse struct {
+	Data []ResourceResponse `json:"data"`
+}
+
+type ResourceResponse struct {
+	Id       string
+	Metadata DeploymentMetaData
+	Spec     ResourceSpec
+}
+
+type PodsResponse struct {
+	Data []apiv1.Pod `json:"data"`
+}
+
+type ResourceSpec struct {
+	VpaObjectName string `json:"vpaObjectName"`
 }
 
 type ContainerPort struct {
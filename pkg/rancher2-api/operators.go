@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rancher2_api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	kubernetes_api "github.com/SENERGY-Platform/analytics-flow-engine/pkg/kubernetes-api"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"github.com/parnurzeal/gorequest"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetOperatorsStatus returns the container state of each operator in the pods of the pipeline workload.
+func (r *Rancher2) GetOperatorsStatus(pipelineId string, operators []pipe.Operator) (statuses []lib.OperatorStatus, err error) {
+	name := r.getOperatorName(pipelineId, pipe.Operator{Id: "v3-123456789"})[1]
+	var deployment appsv1.Deployment
+	if err = r.getKubeResource("apps.deployments/"+r.r2cfg.NamespaceId+"/"+name, &deployment); err != nil {
+		return
+	}
+	var pods PodsResponse
+	selector := url.QueryEscape(metav1.FormatLabelSelector(deployment.Spec.Selector))
+	if err = r.getKubeResource("pods/"+r.r2cfg.NamespaceId+"?labelSelector="+selector, &pods); err != nil {
+		return
+	}
+	return kubernetes_api.OperatorsStatus(pods.Data, operators), nil
+}
+
+// RestartOperator is an input error, as all operators of a pipeline run in the same pods.
+func (r *Rancher2) RestartOperator(_ string, operator pipe.Operator) error {
+	return lib.NewInputError(fmt.Errorf("operator %s shares its pods with the other operators of the pipeline, restart the pipeline instead", operator.Id))
+}
+
+func (r *Rancher2) getKubeResource(path string, v any) error {
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e := request.Get(r.kubeUrl + path).End()
+	if len(e) > 0 {
+		return errors.New("rancher2 API - could not request " + path + " - " + e[0].Error())
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return lib.NewNotFoundError(errors.New("rancher2 API - " + path + " does not exist"))
+	default:
+		return errors.New("rancher2 API - " + path + " response is not ok - " + strconv.Itoa(resp.StatusCode) + " - " + body)
+	}
+	return json.Unmarshal([]byte(body), v)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rancher2_api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	"github.com/parnurzeal/gorequest"
+)
+
+// GetPipelineResources lists all workloads, volumes, autoscalers and autoscaler checkpoints
+// in the namespace which belong to a pipeline, either by their pipelineId label or by their name.
+func (r *Rancher2) GetPipelineResources() (resources []lib.PipelineResource, err error) {
+	deployments, err := r.listResources("apps.deployments")
+	if err != nil {
+		return
+	}
+	for _, deployment := range deployments {
+		labels := deployment.Metadata.Labels
+		pipelineId := labels["pipelineId"]
+		if pipelineId == "" {
+			pipelineId = pipelineIdFromDeploymentName(deployment.Metadata.Name)
+		}
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindDeployment,
+			Name:       deployment.Metadata.Name,
+			PipelineId: pipelineId,
+			FlowId:     labels["flowId"],
+			UserId:     labels["user"],
+		})
+	}
+
+	pvcs, err := r.listResources("persistentvolumeclaims")
+	if err != nil {
+		return
+	}
+	for _, pvc := range pvcs {
+		pipelineId := pipelineIdFromVolumeName(pvc.Metadata.Name)
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindPersistentVolumeClaim,
+			Name:       pvc.Metadata.Name,
+			PipelineId: pipelineId,
+		})
+	}
+
+	vpas, err := r.listResources("autoscaling.k8s.io.verticalpodautoscalers")
+	if err != nil {
+		return
+	}
+	for _, vpa := range vpas {
+		pipelineId := pipelineIdFromDeploymentName(strings.TrimSuffix(vpa.Metadata.Name, "-vpa"))
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindVerticalPodAutoscaler,
+			Name:       vpa.Metadata.Name,
+			PipelineId: pipelineId,
+		})
+	}
+
+	checkpoints, err := r.listResources("autoscaling.k8s.io.verticalpodautoscalercheckpoints")
+	if err != nil {
+		return
+	}
+	for _, checkpoint := range checkpoints {
+		if !strings.HasSuffix(checkpoint.Spec.VpaObjectName, "-vpa") {
+			continue
+		}
+		pipelineId := pipelineIdFromDeploymentName(strings.TrimSuffix(checkpoint.Spec.VpaObjectName, "-vpa"))
+		if pipelineId == "" {
+			continue
+		}
+		resources = append(resources, lib.PipelineResource{
+			Kind:       lib.ResourceKindVerticalPodAutoscalerCheckpoint,
+			Name:       checkpoint.Metadata.Name,
+			PipelineId: pipelineId,
+		})
+	}
+	return
+}
+
+// DeletePipelineResource deletes a single resource previously returned by GetPipelineResources.
+func (r *Rancher2) DeletePipelineResource(resource lib.PipelineResource) (err error) {
+	util.Logger.Debug("deleting pipeline resource", "resource", resource)
+	switch resource.Kind {
+	case lib.ResourceKindDeployment:
+		request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+		resp, body, e := request.Delete(r.url + "projects/" + r.r2cfg.ProjectId + "/workloads/deployment:" +
+			r.r2cfg.NamespaceId + ":" + resource.Name).End()
+		if len(e) > 0 {
+			return ErrSomethingWentWrong
+		}
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return errors.New("rancher2 API - could not delete workload " + body)
+		}
+		return nil
+	case lib.ResourceKindPersistentVolumeClaim:
+		return r.deletePersistentVolumeClaim(resource.Name)
+	case lib.ResourceKindVerticalPodAutoscaler:
+		return r.deleteResource("autoscaling.k8s.io.verticalpodautoscalers", resource.Name)
+	case lib.ResourceKindVerticalPodAutoscalerCheckpoint:
+		return r.deleteResource("autoscaling.k8s.io.verticalpodautoscalercheckpoints", resource.Name)
+	default:
+		return lib.NewInputError(errors.New("unknown resource kind: " + resource.Kind))
+	}
+}
+
+func (r *Rancher2) listResources(resourceType string) (resources []ResourceResponse, err error) {
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e := request.Get(r.kubeUrl + resourceType + "/" + r.r2cfg.NamespaceId).Send(nil).End()
+	if len(e) > 0 {
+		err = errors.New("rancher2 API - could not list " + resourceType + " - " + e[0].Error())
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		err = errors.New("rancher2 API - could not list " + resourceType + " - " + strconv.Itoa(resp.StatusCode) + " - " + body)
+		return
+	}
+	var response ResourcesResponse
+	err = json.Unmarshal([]byte(body), &response)
+	if err != nil {
+		util.Logger.Error("rancher2 API - cannot unmarshal "+resourceType+" response", "error", err)
+		return
+	}
+	return response.Data, nil
+}
+
+func (r *Rancher2) deleteResource(resourceType string, name string) error {
+	request := gorequest.New().SetBasicAuth(r.accessKey, r.secretKey).TLSClientConfig(&tls.Config{InsecureSkipVerify: false})
+	resp, body, e := request.Delete(r.kubeUrl + resourceType + "/" + r.r2cfg.NamespaceId + "/" + name).End()
+	if len(e) > 0 {
+		return ErrSomethingWentWrong
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return errors.New("rancher2 API - could not delete " + resourceType + " " + name + " " + body)
+	}
+	return nil
+}
+
+func pipelineIdFromDeploymentName(name string) string {
+	if !strings.HasPrefix(name, "pipeline-") {
+		return ""
+	}
+	return strings.TrimPrefix(name, "pipeline-")
+}
+
+func pipelineIdFromVolumeName(name string) string {
+	if !strings.HasPrefix(name, "operator-") {
+		return ""
+	}
+	trimmed := strings.TrimPrefix(name, "operator-")
+	if len(trimmed) < 10 || trimmed[len(trimmed)-9] != '-' {
+		return ""
+	}
+	return trimmed[:len(trimmed)-9]
+}
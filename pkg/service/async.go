@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+)
+
+var errQueueFull = errors.New("operation queue is full")
+
+// runWorker processes queued operations until ctx is cancelled.
+// Operations still queued at shutdown stay pending in the journal and are rolled back on the next start,
+// a queued deletion is dropped as none of its steps ran.
+func (f *FlowEngine) runWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-f.queue:
+			job()
+		}
+	}
+}
+
+// enqueue schedules job for the operation of s and returns the operation as recorded before processing.
+func (f *FlowEngine) enqueue(s *saga, job func()) (operation lib.Operation, err error) {
+	operation, _ = f.operations.get(s.operation.Id)
+	select {
+	case f.queue <- job:
+		util.Logger.Debug("queued operation", "operation", operation.Id, "type", operation.Type)
+		return
+	default:
+		err = s.fail(errQueueFull)
+		return operation, lib.NewUnavailableError(err)
+	}
+}
+
+// StartPipelineAsync queues the start of a pipeline and returns the operation to poll.
+func (f *FlowEngine) StartPipelineAsync(pipelineRequest lib.PipelineRequest, userId string, token string) (lib.Operation, error) {
+	s := f.newSaga(lib.OperationTypeStart, "", userId)
+	return f.enqueue(s, func() {
+		_, _ = f.startPipeline(s, pipelineRequest, userId, token)
+	})
+}
+
+// UpdatePipelineAsync queues the update of a pipeline and returns the operation to poll.
+func (f *FlowEngine) UpdatePipelineAsync(pipelineRequest lib.PipelineRequest, userId string, token string) (lib.Operation, error) {
+	s := f.newSaga(lib.OperationTypeUpdate, pipelineRequest.Id, userId)
+	return f.enqueue(s, func() {
+		_, _ = f.updatePipeline(s, pipelineRequest, userId, token)
+	})
+}
+
+// DeletePipelineAsync queues the deletion of a pipeline and returns the operation to poll.
+// The pipeline has to belong to the user, as the journaled operation only holds its ID.
+func (f *FlowEngine) DeletePipelineAsync(id string, userId string, token string) (lib.Operation, error) {
+	if _, err := f.pipelineService.GetPipeline(id, userId, token); err != nil {
+		return lib.Operation{}, err
+	}
+	s := f.newSaga(lib.OperationTypeDelete, id, userId)
+	return f.enqueue(s, func() {
+		_ = f.deletePipeline(s, id, userId, token)
+	})
+}
+
+// GetUserOperation returns a recorded operation if it was started by the user.
+func (f *FlowEngine) GetUserOperation(id string, userId string) (operation lib.Operation, err error) {
+	operation, err = f.GetOperation(id)
+	if err != nil {
+		return
+	}
+	if operation.UserId != userId {
+		return lib.Operation{}, lib.NewNotFoundError(errors.New("operation not found"))
+	}
+	return
+}
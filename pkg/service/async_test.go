@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+)
+
+func TestFlowEngine_enqueue(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	f.queue = make(chan func(), 1)
+
+	queued, err := f.enqueue(f.newSaga(lib.OperationTypeDelete, "pid", "user"), func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued.State != lib.OperationStatePending {
+		t.Errorf("expected pending operation, got %s", queued.State)
+	}
+
+	rejected, err := f.enqueue(f.newSaga(lib.OperationTypeDelete, "pid", "user"), func() {})
+	var unavailableErr *lib.UnavailableError
+	if !errors.As(err, &unavailableErr) {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+	if operation, _ := f.GetOperation(rejected.Id); operation.State != lib.OperationStateCompensated || operation.Error == "" {
+		t.Errorf("expected rejected operation to be closed with error, got %+v", operation)
+	}
+
+	if _, err = f.GetUserOperation(queued.Id, "other"); err == nil {
+		t.Error("expected operation of another user to be hidden")
+	}
+	if _, err = f.GetUserOperation(queued.Id, "user"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// ExecuteBatch executes the actions with at most the configured batch concurrency at the same time.
+// Pipelines and errors are returned in the order of the actions, the pipeline is only set for start and update.
+func (f *FlowEngine) ExecuteBatch(actions []lib.BatchAction, userId, token string) (pipelines []*pipe.Pipeline, errs []error) {
+	pipelines = make([]*pipe.Pipeline, len(actions))
+	errs = make([]error, len(actions))
+	sem := make(chan struct{}, max(f.batchConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, action := range actions {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			pipelines[i], errs[i] = f.executeBatchAction(action, userId, token)
+		})
+	}
+	wg.Wait()
+	return
+}
+
+func (f *FlowEngine) executeBatchAction(action lib.BatchAction, userId, token string) (*pipe.Pipeline, error) {
+	switch action.Action {
+	case lib.BatchActionStart, lib.BatchActionUpdate:
+		if action.Request == nil {
+			return nil, lib.NewInputError(errors.New("missing pipeline request"))
+		}
+		if err := ValidateSchedule(action.Request.Schedule); err != nil {
+			return nil, lib.NewInputError(err)
+		}
+		if action.Action == lib.BatchActionStart {
+			return f.StartPipeline(*action.Request, userId, token)
+		}
+		if !slices.Contains([]string{"", lib.UpdateStrategyRecreate, lib.UpdateStrategyBlueGreen}, action.Request.UpdateStrategy) {
+			return nil, lib.NewInputError(fmt.Errorf("unknown update strategy %q", action.Request.UpdateStrategy))
+		}
+		return f.UpdatePipeline(*action.Request, userId, token)
+	}
+	if action.PipelineId == "" {
+		return nil, lib.NewInputError(errors.New("missing pipeline ID"))
+	}
+	switch action.Action {
+	case lib.BatchActionDelete:
+		return nil, f.DeletePipeline(action.PipelineId, userId, token)
+	case lib.BatchActionPause:
+		return nil, f.PausePipeline(action.PipelineId, userId, token)
+	case lib.BatchActionResume:
+		return nil, f.ResumePipeline(action.PipelineId, userId, token)
+	case lib.BatchActionRestart:
+		return nil, f.RestartPipeline(action.PipelineId, userId, token)
+	}
+	return nil, lib.NewInputError(fmt.Errorf("unknown action %q", action.Action))
+}
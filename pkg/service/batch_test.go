@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_ExecuteBatch(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{deleteDelay: 10 * time.Millisecond}
+	f := newTestEngine(newFakeDriver(), pipelines)
+	f.batchConcurrency = 2
+	var actions []lib.BatchAction
+	for i := range 6 {
+		pipelines.pipelines = append(pipelines.pipelines, pipe.Pipeline{Id: strconv.Itoa(i)})
+		actions = append(actions, lib.BatchAction{Action: lib.BatchActionDelete, PipelineId: strconv.Itoa(i)})
+	}
+	actions = append(actions,
+		lib.BatchAction{Action: lib.BatchActionDelete, PipelineId: "unknown"},
+		lib.BatchAction{Action: lib.BatchActionPause},
+		lib.BatchAction{Action: "unknown", PipelineId: "0"},
+		lib.BatchAction{Action: lib.BatchActionStart},
+	)
+
+	_, errs := f.ExecuteBatch(actions, "user", "")
+
+	if len(pipelines.deleted) != 6 {
+		t.Errorf("expected 6 deleted pipelines, got %v", pipelines.deleted)
+	}
+	if maxRun := pipelines.maxDeleting.Load(); maxRun > 2 {
+		t.Errorf("expected at most 2 concurrent actions, got %d", maxRun)
+	}
+	for i := range 6 {
+		if errs[i] != nil {
+			t.Errorf("unexpected error for action %d: %v", i, errs[i])
+		}
+	}
+	if !errors.As(errs[6], new(*lib.NotFoundError)) {
+		t.Errorf("expected not found error, got %v", errs[6])
+	}
+	for i := 7; i < len(actions); i++ {
+		if !errors.As(errs[i], new(*lib.InputError)) {
+			t.Errorf("expected input error for action %d, got %v", i, errs[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	deploymentLocationLib "github.com/SENERGY-Platform/analytics-fog-lib/lib/location"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// blueGreenDriver returns the versioned driver if the update should run blue/green.
+// An empty strategy selects the configured default. Updates fall back to recreate if the driver
+// can only run a single version or a cloud operator persists data, as its volume can only be mounted once.
+func (f *FlowEngine) blueGreenDriver(strategy string, pipelines ...pipe.Pipeline) (VersionedDriver, bool) {
+	if strategy == "" {
+		strategy = f.updateCfg.Strategy
+	}
+	if strategy != lib.UpdateStrategyBlueGreen {
+		return nil, false
+	}
+	versionedDriver, ok := f.driver.(VersionedDriver)
+	if !ok {
+		util.Logger.Warn("driver does not support blue/green updates, falling back to recreate")
+		return nil, false
+	}
+	for _, pipeline := range pipelines {
+		for _, operator := range pipeline.Operators {
+			if operator.PersistData && operator.DeploymentType != deploymentLocationLib.Local {
+				util.Logger.Info("operator persists data, falling back to recreate", "pipeline", pipeline.Id, "operator", operator.Id)
+				return nil, false
+			}
+		}
+	}
+	return versionedDriver, true
+}
+
+// createVersion creates the cloud operators of the updated pipeline as a new version next to the running one
+// and waits until it is healthy. If it does not become healthy in time, compensating the saga deletes it
+// and the old version keeps running.
+func (f *FlowEngine) createVersion(s *saga, driver VersionedDriver, pipelineId string, cloudOperators []pipe.Operator, pipeConfig lib.PipelineConfig) (err error) {
+	version := strconv.FormatInt(time.Now().UnixMilli(), 36)
+	data := map[string]string{"version": version}
+	util.Logger.Debug("creating new version of cloud operators", "pipeline", pipelineId, "version", version)
+	err = s.step(stepCreateVersion, data, func() error {
+		return retry(6, 10*time.Second, func() error {
+			return driver.CreateOperatorsVersion(pipelineId, version, cloudOperators, pipeConfig)
+		})
+	}, func() error {
+		return driver.DeleteOperatorsVersion(pipelineId, version, cloudOperators)
+	})
+	if err != nil {
+		return
+	}
+	s.advance(lib.OperationStateDriverCreated)
+	return s.step(stepAwaitVersion, data, func() error {
+		return f.awaitVersion(driver, pipelineId, version)
+	}, nil)
+}
+
+// awaitVersion polls the status of a version until it is running or the update timeout is exceeded.
+func (f *FlowEngine) awaitVersion(driver VersionedDriver, pipelineId, version string) error {
+	deadline := time.Now().Add(f.updateCfg.Timeout)
+	for {
+		status, err := driver.GetPipelineVersionStatus(pipelineId, version)
+		if err != nil {
+			util.Logger.Debug("cannot get status of new version", "pipeline", pipelineId, "version", version, "error", err)
+		} else if status.Running {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("version %s of pipeline %s did not become healthy within %s", version, pipelineId, f.updateCfg.Timeout)
+		}
+		time.Sleep(f.updateCfg.PollInterval)
+	}
+}
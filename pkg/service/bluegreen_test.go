@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_updateOperators_blueGreen(t *testing.T) {
+	util.InitStructLogger("error")
+	oldPipeline := pipe.Pipeline{Id: "pid", Operators: []pipe.Operator{{Id: "old", DeploymentType: "cloud"}}}
+	pipeline := pipe.Pipeline{Id: "pid", Operators: []pipe.Operator{{Id: "new", DeploymentType: "cloud"}}}
+
+	t.Run("healthy", func(t *testing.T) {
+		driver := &fakeVersionedDriver{fakeDriver: newFakeDriver(), healthy: true, versions: []string{""}}
+		f := newTestEngine(driver, nil)
+		f.updateCfg = config.UpdateConfig{Strategy: lib.UpdateStrategyBlueGreen}
+		versionedDriver, ok := f.blueGreenDriver("", oldPipeline, pipeline)
+		if !ok {
+			t.Fatal("expected blue/green update")
+		}
+		s := f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+		operators, err := f.updateOperators(s, versionedDriver, oldPipeline, pipeline, lib.PipelineConfig{}, "user", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(operators) != 1 || operators[0].Id != "new" {
+			t.Errorf("unexpected operators %+v", operators)
+		}
+		if len(driver.versions) != 1 || driver.versions[0] == "" {
+			t.Errorf("expected only the new version to remain, got %v", driver.versions)
+		}
+		if !slices.Equal(driver.deletedVersions, []string{""}) {
+			t.Errorf("expected old version to be deleted, got %v", driver.deletedVersions)
+		}
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		driver := &fakeVersionedDriver{fakeDriver: newFakeDriver(), versions: []string{""}}
+		f := newTestEngine(driver, nil)
+		f.updateCfg = config.UpdateConfig{Timeout: 10 * time.Millisecond, PollInterval: time.Millisecond}
+		versionedDriver, ok := f.blueGreenDriver(lib.UpdateStrategyBlueGreen, oldPipeline, pipeline)
+		if !ok {
+			t.Fatal("expected blue/green update")
+		}
+		s := f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+		_, err := f.updateOperators(s, versionedDriver, oldPipeline, pipeline, lib.PipelineConfig{}, "user", "")
+		if err == nil {
+			t.Fatal("expected error for unhealthy version")
+		}
+		_ = s.fail(err)
+		if !slices.Equal(driver.versions, []string{""}) {
+			t.Errorf("expected only the old version to remain, got %v", driver.versions)
+		}
+		if s.operation.State != lib.OperationStateCompensated {
+			t.Errorf("expected compensated operation, got %s", s.operation.State)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		f := newTestEngine(newFakeDriver(), nil)
+		f.updateCfg = config.UpdateConfig{Strategy: lib.UpdateStrategyBlueGreen}
+		if _, ok := f.blueGreenDriver("", oldPipeline, pipeline); ok {
+			t.Error("expected recreate for driver without versions")
+		}
+		f.driver = &fakeVersionedDriver{fakeDriver: newFakeDriver()}
+		persisting := pipe.Pipeline{Operators: []pipe.Operator{{Id: "op", DeploymentType: "cloud", PersistData: true}}}
+		if _, ok := f.blueGreenDriver("", oldPipeline, persisting); ok {
+			t.Error("expected recreate for operators persisting data")
+		}
+		if _, ok := f.blueGreenDriver(lib.UpdateStrategyRecreate, oldPipeline, pipeline); ok {
+			t.Error("expected recreate if requested")
+		}
+	})
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	deploymentLocationLib "github.com/SENERGY-Platform/analytics-fog-lib/lib/location"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// ClonePipeline starts a copy of a registered pipeline with the overrides of request and the schedule, resources
+// and deployment mode of the original.
+// The copy is set up from its flow like a new pipeline, so permissions are checked again and operators get new application IDs.
+func (f *FlowEngine) ClonePipeline(id string, request lib.PipelineCloneRequest, userId, token string) (*pipe.Pipeline, error) {
+	util.Logger.Debug("engine - clone pipeline: " + id)
+	source, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return nil, err
+	}
+	pipelineRequest, err := clonePipelineRequest(source, request)
+	if err != nil {
+		return nil, err
+	}
+	pipelineRequest.Schedule = f.getSchedule(id)
+	pipelineRequest.DeploymentMode = f.getDeploymentMode(id)
+	resources := f.getResources(id)
+	for i, node := range pipelineRequest.Nodes {
+		if operatorResources, ok := resources[node.NodeId]; ok {
+			pipelineRequest.Nodes[i].Resources = &operatorResources
+		}
+	}
+	return f.StartPipeline(pipelineRequest, userId, token)
+}
+
+// clonePipelineRequest derives the request which would create pipeline and applies the overrides.
+// The inputs of local operators can not be derived, as they were translated to local topics, and have to be overridden.
+func clonePipelineRequest(pipeline pipe.Pipeline, overrides lib.PipelineCloneRequest) (request lib.PipelineRequest, err error) {
+	request = lib.PipelineRequest{
+		FlowId:             pipeline.FlowId,
+		Name:               pipeline.Name,
+		Description:        pipeline.Description,
+		WindowTime:         pipeline.WindowTime,
+		MergeStrategy:      pipeline.MergeStrategy,
+		ConsumeAllMessages: pipeline.ConsumeAllMessages,
+		Metrics:            pipeline.Metrics,
+	}
+	if overrides.Name != "" {
+		request.Name = overrides.Name
+	}
+	if overrides.Description != "" {
+		request.Description = overrides.Description
+	}
+
+	operatorIds := make([]string, 0, len(pipeline.Operators))
+	for _, operator := range pipeline.Operators {
+		operatorIds = append(operatorIds, operator.Id)
+	}
+	var local []string
+	for _, operator := range pipeline.Operators {
+		node := lib.PipelineNode{
+			NodeId:          operator.Id,
+			InputSelections: operator.InputSelections,
+			PersistData:     operator.PersistData,
+		}
+		for _, name := range slices.Sorted(maps.Keys(operator.Config)) {
+			node.Config = append(node.Config, lib.NodeConfig{Name: name, Value: operator.Config[name]})
+		}
+		inputs := nodeInputs(operator, operatorIds)
+		if operator.DeploymentType == deploymentLocationLib.Local && len(inputs) > 0 {
+			local = append(local, operator.Id)
+		} else {
+			node.Inputs = inputs
+		}
+		request.Nodes = append(request.Nodes, node)
+	}
+
+	for _, override := range overrides.Nodes {
+		idx := slices.IndexFunc(request.Nodes, func(node lib.PipelineNode) bool { return node.NodeId == override.NodeId })
+		if idx == -1 {
+			return request, lib.NewInputError(fmt.Errorf("pipeline has no node %s", override.NodeId))
+		}
+		node := &request.Nodes[idx]
+		if len(override.Inputs) > 0 {
+			node.Inputs = override.Inputs
+			local = slices.DeleteFunc(local, func(id string) bool { return id == override.NodeId })
+		}
+		for _, config := range override.Config {
+			configIdx := slices.IndexFunc(node.Config, func(c lib.NodeConfig) bool { return c.Name == config.Name })
+			if configIdx == -1 {
+				node.Config = append(node.Config, config)
+			} else {
+				node.Config[configIdx] = config
+			}
+		}
+	}
+	if len(local) > 0 {
+		return request, lib.NewInputError(fmt.Errorf("inputs of local operators %v have to be given", local))
+	}
+	return request, nil
+}
+
+// nodeInputs returns the inputs of an operator which do not come from other operators of the pipeline.
+func nodeInputs(operator pipe.Operator, operatorIds []string) (inputs []lib.NodeInput) {
+	for _, topic := range operator.InputTopics {
+		if slices.Contains(operatorIds, topic.FilterValue) {
+			continue
+		}
+		input := lib.NodeInput{FilterIds: topic.FilterValue, TopicName: topic.Name}
+		switch topic.FilterType {
+		case "OperatorId":
+			input.FilterType = RequestOperatorId
+		case "ImportId":
+			input.FilterType = RequestImportId
+		default:
+			input.FilterType = RequestDeviceId
+		}
+		for _, mapping := range topic.Mappings {
+			input.Values = append(input.Values, lib.NodeValue{Name: mapping.Dest, Path: mapping.Source})
+		}
+		inputs = append(inputs, input)
+	}
+	return
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestClonePipelineRequest(t *testing.T) {
+	pipeline := pipe.Pipeline{
+		Id:         "pid",
+		Name:       "group a",
+		FlowId:     "flow",
+		WindowTime: 30,
+		Operators: []pipe.Operator{
+			{
+				Id:             "first",
+				DeploymentType: "cloud",
+				Config:         map[string]string{"b": "2", "a": "1"},
+				InputTopics: []pipe.InputTopic{
+					{Name: "service", FilterType: "DeviceId", FilterValue: "device", Mappings: []pipe.Mapping{{Dest: "value", Source: "value.root.x"}}},
+				},
+			},
+			{
+				Id:             "second",
+				DeploymentType: "cloud",
+				InputTopics:    []pipe.InputTopic{{Name: "first-topic", FilterType: "OperatorId", FilterValue: "first"}},
+			},
+		},
+	}
+
+	request, err := clonePipelineRequest(pipeline, lib.PipelineCloneRequest{
+		Name: "group b",
+		Nodes: []lib.PipelineNode{{
+			NodeId: "first",
+			Config: []lib.NodeConfig{{Name: "b", Value: "3"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.Name != "group b" || request.FlowId != "flow" || request.WindowTime != 30 {
+		t.Errorf("unexpected request %+v", request)
+	}
+	expected := []lib.PipelineNode{
+		{
+			NodeId: "first",
+			Config: []lib.NodeConfig{{Name: "a", Value: "1"}, {Name: "b", Value: "3"}},
+			Inputs: []lib.NodeInput{{FilterType: RequestDeviceId, FilterIds: "device", TopicName: "service", Values: []lib.NodeValue{{Name: "value", Path: "value.root.x"}}}},
+		},
+		{NodeId: "second"},
+	}
+	if !reflect.DeepEqual(request.Nodes, expected) {
+		t.Errorf("unexpected nodes %+v", request.Nodes)
+	}
+
+	inputs := []lib.NodeInput{{FilterType: RequestDeviceId, FilterIds: "other", TopicName: "service"}}
+	request, err = clonePipelineRequest(pipeline, lib.PipelineCloneRequest{Nodes: []lib.PipelineNode{{NodeId: "first", Inputs: inputs}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(request.Nodes[0].Inputs, inputs) || request.Name != "group a" {
+		t.Errorf("unexpected request %+v", request)
+	}
+
+	var inputErr *lib.InputError
+	if _, err = clonePipelineRequest(pipeline, lib.PipelineCloneRequest{Nodes: []lib.PipelineNode{{NodeId: "unknown"}}}); !errors.As(err, &inputErr) {
+		t.Errorf("expected input error for unknown node, got %v", err)
+	}
+	pipeline.Operators[0].DeploymentType = "local"
+	if _, err = clonePipelineRequest(pipeline, lib.PipelineCloneRequest{}); !errors.As(err, &inputErr) {
+		t.Errorf("expected input error for local operator without inputs, got %v", err)
+	}
+}
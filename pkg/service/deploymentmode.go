@@ -0,0 +1,81 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+)
+
+// ValidateDeploymentMode returns an error if mode is neither the pipeline nor the operator deployment mode.
+func ValidateDeploymentMode(mode string) error {
+	if !slices.Contains([]string{lib.DeploymentModePipeline, lib.DeploymentModeOperator}, mode) {
+		return lib.NewInputError(fmt.Errorf("unknown deployment mode %q", mode))
+	}
+	return nil
+}
+
+// resolveDeploymentMode returns the deployment mode of the request, the configured default if none is set.
+func (f *FlowEngine) resolveDeploymentMode(request lib.PipelineRequest) (string, error) {
+	if request.DeploymentMode == "" {
+		return f.deploymentMode, nil
+	}
+	return request.DeploymentMode, ValidateDeploymentMode(request.DeploymentMode)
+}
+
+// storeDeploymentMode records the deployment mode of a pipeline as step of s.
+func (f *FlowEngine) storeDeploymentMode(s *saga, pipelineId, mode string) error {
+	previous, err := f.deploymentModes.Get(pipelineId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if previous == mode {
+		return nil
+	}
+	data := map[string]string{"mode": previous}
+	return s.step(stepStoreDeploymentMode, data, func() error {
+		return f.deploymentModes.Put(pipelineId, mode)
+	}, func() error {
+		return f.restoreDeploymentMode(pipelineId, data)
+	})
+}
+
+// restoreDeploymentMode puts back the deployment mode recorded in the data of a store deployment mode step.
+func (f *FlowEngine) restoreDeploymentMode(pipelineId string, data map[string]string) error {
+	if data["mode"] == "" {
+		return f.deploymentModes.Delete(pipelineId)
+	}
+	return f.deploymentModes.Put(pipelineId, data["mode"])
+}
+
+// getDeploymentMode returns the stored deployment mode of a pipeline,
+// pipelines started before the mode was selectable run in the pipeline mode.
+func (f *FlowEngine) getDeploymentMode(id string) string {
+	mode, err := f.deploymentModes.Get(id)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			util.Logger.Error("cannot get deployment mode", "pipeline", id, "error", err)
+		}
+		return lib.DeploymentModePipeline
+	}
+	return mode
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_deploymentMode(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	f.deploymentMode = lib.DeploymentModeOperator
+	if mode, err := f.resolveDeploymentMode(lib.PipelineRequest{}); err != nil || mode != lib.DeploymentModeOperator {
+		t.Errorf("expected configured default, got %q, %v", mode, err)
+	}
+	var inputErr *lib.InputError
+	if _, err := f.resolveDeploymentMode(lib.PipelineRequest{DeploymentMode: "node"}); !errors.As(err, &inputErr) {
+		t.Errorf("expected input error, got %v", err)
+	}
+
+	pipeline := pipe.Pipeline{Id: "pid", Operators: []pipe.Operator{{Id: "op", DeploymentType: "cloud"}}}
+	running := f.createPipelineConfig(pipeline)
+	if running.DeploymentMode != lib.DeploymentModePipeline {
+		t.Errorf("expected pipelines without stored mode to run in the pipeline mode, got %q", running.DeploymentMode)
+	}
+	pipeConfig := running
+	pipeConfig.DeploymentMode = lib.DeploymentModeOperator
+	if !redeployRequired(pipeline, running, pipeConfig) {
+		t.Error("expected redeploy when the deployment mode changes")
+	}
+
+	s := f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+	if err := f.storeDeploymentMode(s, "pid", lib.DeploymentModeOperator); err != nil {
+		t.Fatal(err)
+	}
+	if f.getDeploymentMode("pid") != lib.DeploymentModeOperator {
+		t.Error("expected stored deployment mode")
+	}
+	_ = s.fail(errInterrupted)
+	if _, err := f.deploymentModes.Get("pid"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected deployment mode to be removed by compensation, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	deploymentLocationLib "github.com/SENERGY-Platform/analytics-fog-lib/lib/location"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// operatorDiff describes what an update has to restart.
+// The cloud operators are deployed together, even in the operator deployment mode, so they are redeployed as a whole if any of them changed.
+// Forwarding instances of cloud operators and local operators are only restarted if they changed.
+type operatorDiff struct {
+	redeployCloud bool
+	// stop is the old pipeline with the operators whose forwarding or fog instance has to be stopped
+	stop pipe.Pipeline
+	// start is the new pipeline with the operators whose forwarding or fog instance has to be started
+	start pipe.Pipeline
+	// kept are the new operators which keep running, with the forwarding instance of the old ones
+	kept []pipe.Operator
+}
+
+// diffOperators compares the operators of two versions of a pipeline by their ID.
+// Both pipelines must already have the pipeline ID added to their fog topics.
+func diffOperators(oldPipeline, pipeline pipe.Pipeline) (diff operatorDiff) {
+	diff.stop = oldPipeline
+	diff.stop.Operators = nil
+	diff.start = pipeline
+	diff.start.Operators = nil
+	diff.redeployCloud = pipelineConfigChanged(oldPipeline, pipeline)
+
+	oldOperators := make(map[string]pipe.Operator)
+	for _, operator := range oldPipeline.Operators {
+		oldOperators[operator.Id] = operator
+	}
+	for _, operator := range pipeline.Operators {
+		oldOperator, exists := oldOperators[operator.Id]
+		delete(oldOperators, operator.Id)
+		changed := !exists || operatorChanged(oldOperator, operator)
+		if changed && (isCloudOperator(operator) || (exists && isCloudOperator(oldOperator))) {
+			diff.redeployCloud = true
+		}
+		if exists && !forwardingChanged(oldOperator, operator) {
+			operator.DownstreamConfig.InstanceID = oldOperator.DownstreamConfig.InstanceID
+			diff.kept = append(diff.kept, operator)
+			continue
+		}
+		if exists {
+			diff.stop.Operators = append(diff.stop.Operators, oldOperator)
+		}
+		diff.start.Operators = append(diff.start.Operators, operator)
+	}
+	// keep the order of the old pipeline for removed operators
+	for _, operator := range oldPipeline.Operators {
+		if _, removed := oldOperators[operator.Id]; !removed {
+			continue
+		}
+		if isCloudOperator(operator) {
+			diff.redeployCloud = true
+		}
+		diff.stop.Operators = append(diff.stop.Operators, operator)
+	}
+	return
+}
+
+// pipelineConfigChanged reports whether the pipeline wide settings passed to every cloud operator changed.
+func pipelineConfigChanged(oldPipeline, pipeline pipe.Pipeline) bool {
+	return oldPipeline.WindowTime != pipeline.WindowTime ||
+		oldPipeline.MergeStrategy != pipeline.MergeStrategy ||
+		oldPipeline.ConsumeAllMessages != pipeline.ConsumeAllMessages ||
+		oldPipeline.FlowId != pipeline.FlowId
+}
+
+// operatorChanged reports whether an operator has to be redeployed.
+func operatorChanged(oldOperator, operator pipe.Operator) bool {
+	return oldOperator.ImageId != operator.ImageId ||
+		oldOperator.OperatorId != operator.OperatorId ||
+		oldOperator.DeploymentType != operator.DeploymentType ||
+		oldOperator.PersistData != operator.PersistData ||
+		oldOperator.OutputTopic != operator.OutputTopic ||
+		oldOperator.ApplicationId != operator.ApplicationId ||
+		!maps.Equal(oldOperator.Config, operator.Config) ||
+		!slices.EqualFunc(oldOperator.InputTopics, operator.InputTopics, func(a, b pipe.InputTopic) bool {
+			return reflect.DeepEqual(a, b)
+		})
+}
+
+// forwardingChanged reports whether the kafka2mqtt instance of a cloud operator
+// or the fog instance and upstream forwarding of a local operator have to be restarted.
+func forwardingChanged(oldOperator, operator pipe.Operator) bool {
+	if oldOperator.DeploymentType != operator.DeploymentType {
+		return true
+	}
+	if isCloudOperator(operator) {
+		return oldOperator.Name != operator.Name ||
+			oldOperator.DownstreamConfig.Enabled != operator.DownstreamConfig.Enabled ||
+			(operator.DownstreamConfig.Enabled && oldOperator.DownstreamConfig.InstanceID == "")
+	}
+	return operatorChanged(oldOperator, operator) || oldOperator.UpstreamConfig != operator.UpstreamConfig
+}
+
+func isCloudOperator(operator pipe.Operator) bool {
+	return operator.DeploymentType != deploymentLocationLib.Local
+}
+
+// mergeOperators replaces the operators by the updated ones with the same ID.
+func mergeOperators(operators []pipe.Operator, updated ...[]pipe.Operator) (merged []pipe.Operator) {
+	byId := make(map[string]pipe.Operator)
+	for _, u := range updated {
+		for _, operator := range u {
+			byId[operator.Id] = operator
+		}
+	}
+	for _, operator := range operators {
+		if u, ok := byId[operator.Id]; ok {
+			operator = u
+		}
+		merged = append(merged, operator)
+	}
+	return
+}
+
+// stepData records the diff with the step stopping the old operators, so that it can be compensated after a restart.
+func (d operatorDiff) stepData() map[string]string {
+	ids := make([]string, 0, len(d.stop.Operators))
+	for _, operator := range d.stop.Operators {
+		ids = append(ids, operator.Id)
+	}
+	return map[string]string{
+		"redeployCloud": strconv.FormatBool(d.redeployCloud),
+		"operators":     strings.Join(ids, ","),
+	}
+}
+
+// diffFromStepData restores the part of a diff needed to restore the old pipeline.
+// Without recorded data all operators of the old pipeline are restored.
+func diffFromStepData(oldPipeline pipe.Pipeline, data map[string]string) (diff operatorDiff) {
+	diff.stop = oldPipeline
+	redeployCloud, err := strconv.ParseBool(data["redeployCloud"])
+	if err != nil {
+		diff.redeployCloud = true
+		return
+	}
+	diff.redeployCloud = redeployCloud
+	ids := strings.Split(data["operators"], ",")
+	diff.stop.Operators = slices.DeleteFunc(slices.Clone(oldPipeline.Operators), func(operator pipe.Operator) bool {
+		return !slices.Contains(ids, operator.Id)
+	})
+	return
+}
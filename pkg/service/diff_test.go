@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"slices"
+	"testing"
+
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func operatorIds(operators []pipe.Operator) (ids []string) {
+	for _, operator := range operators {
+		ids = append(ids, operator.Id)
+	}
+	return
+}
+
+func TestDiffOperators(t *testing.T) {
+	oldPipeline := pipe.Pipeline{Id: "pid", Operators: []pipe.Operator{
+		{Id: "cloud-1", DeploymentType: "cloud", Config: map[string]string{"a": "1"}, DownstreamConfig: pipe.DownstreamConfig{Enabled: true, InstanceID: "instance-1"}},
+		{Id: "local-1", DeploymentType: "local", Config: map[string]string{"a": "1"}},
+		{Id: "local-2", DeploymentType: "local", Config: map[string]string{"a": "1"}},
+		{Id: "local-3", DeploymentType: "local"},
+	}}
+
+	t.Run("local config changed", func(t *testing.T) {
+		pipeline := pipe.Pipeline{Id: "pid", Operators: slices.Clone(oldPipeline.Operators)}
+		pipeline.Operators[0].DownstreamConfig.InstanceID = ""
+		pipeline.Operators[1] = pipe.Operator{Id: "local-1", DeploymentType: "local", Config: map[string]string{"a": "2"}}
+		pipeline.Operators = pipeline.Operators[:3]
+
+		diff := diffOperators(oldPipeline, pipeline)
+		if diff.redeployCloud {
+			t.Error("cloud operators must not be redeployed")
+		}
+		if ids := operatorIds(diff.stop.Operators); !slices.Equal(ids, []string{"local-1", "local-3"}) {
+			t.Errorf("unexpected stopped operators %v", ids)
+		}
+		if ids := operatorIds(diff.start.Operators); !slices.Equal(ids, []string{"local-1"}) {
+			t.Errorf("unexpected started operators %v", ids)
+		}
+		if ids := operatorIds(diff.kept); !slices.Equal(ids, []string{"cloud-1", "local-2"}) {
+			t.Errorf("unexpected kept operators %v", ids)
+		}
+		if diff.kept[0].DownstreamConfig.InstanceID != "instance-1" {
+			t.Error("kept cloud operator lost its kafka2mqtt instance")
+		}
+		merged := mergeOperators(pipeline.Operators, diff.kept, diff.start.Operators)
+		if merged[0].DownstreamConfig.InstanceID != "instance-1" || merged[1].Config["a"] != "2" {
+			t.Errorf("unexpected merged operators %+v", merged)
+		}
+
+		restored := diffFromStepData(oldPipeline, diff.stepData())
+		if restored.redeployCloud || !slices.Equal(operatorIds(restored.stop.Operators), []string{"local-1", "local-3"}) {
+			t.Errorf("unexpected diff from step data %+v", restored)
+		}
+		if restored = diffFromStepData(oldPipeline, nil); !restored.redeployCloud || len(restored.stop.Operators) != 4 {
+			t.Error("expected full restore without step data")
+		}
+	})
+
+	t.Run("cloud config changed", func(t *testing.T) {
+		pipeline := pipe.Pipeline{Id: "pid", Operators: slices.Clone(oldPipeline.Operators)}
+		pipeline.Operators[0] = pipe.Operator{Id: "cloud-1", DeploymentType: "cloud", Config: map[string]string{"a": "2"}, DownstreamConfig: pipe.DownstreamConfig{Enabled: true}}
+
+		diff := diffOperators(oldPipeline, pipeline)
+		if !diff.redeployCloud {
+			t.Error("expected cloud operators to be redeployed")
+		}
+		if len(diff.stop.Operators) != 0 || len(diff.start.Operators) != 0 {
+			t.Errorf("forwarding and local operators must be kept, stopped %v, started %v", operatorIds(diff.stop.Operators), operatorIds(diff.start.Operators))
+		}
+	})
+
+	t.Run("pipeline config changed", func(t *testing.T) {
+		pipeline := pipe.Pipeline{Id: "pid", WindowTime: 30, Operators: oldPipeline.Operators}
+		if diff := diffOperators(oldPipeline, pipeline); !diff.redeployCloud || len(diff.kept) != 4 {
+			t.Errorf("expected redeploy of cloud operators only, got %+v", diff)
+		}
+	})
+}
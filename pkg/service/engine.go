@@ -17,8 +17,10 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"strings"
 	"time"
@@ -26,6 +28,9 @@ import (
 	"encoding/json"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/kafka2mqtt-api"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
 	parser "github.com/SENERGY-Platform/analytics-parser/lib"
 	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
@@ -43,130 +48,258 @@ type FlowEngine struct {
 	kafak2mqttService    Kafka2MqttApiService
 	deviceManagerService DeviceManagerService
 	pipelineService      PipelineApiService
+	reconcileCfg         config.ReconcileConfig
+	reconcile            *reconcileState
+	gcCfg                config.GarbageCollectionConfig
+	gc                   *gcState
+	updateCfg            config.UpdateConfig
+	locks                *pipelineLocks
+	operations           *operationStore
+	paused               store.Store[lib.PausedPipeline]
+	schedules            store.Store[scheduledPipeline]
+	resources            store.Store[map[string]lib.OperatorResources]
+	resourcesCfg         config.ResourcesConfig
+	deploymentModes      store.Store[string]
+	deploymentMode       string
+	queue                chan func()
+	batchConcurrency     int
+	statusHub            *statusHub
 }
 
+// NewFlowEngine creates the engine, loads the operation journal from cfg.DataDir and starts the operation workers,
+// the background reconciliation, garbage collection, the pipeline scheduler and the status watcher. Operation journaling is disabled if cfg.DataDir is empty.
+// The state of pipelines is kept by the driver if it implements StateStorage, otherwise in cfg.DataDir.
+// If cfg.DataDir is not a volume, its content is lost with the container, which is logged or fails if cfg.RequireDataVolume is set.
 func NewFlowEngine(
+	ctx context.Context,
+	cfg *config.Config,
 	driver Driver,
 	parsingService ParsingApiService,
 	permissionService PermissionApiService,
 	kafak2mqttService Kafka2MqttApiService,
 	deviceManagerService DeviceManagerService,
-	pipelineService PipelineApiService) *FlowEngine {
-	f := &FlowEngine{driver, parsingService, permissionService, kafak2mqttService, deviceManagerService, pipelineService}
-	err := f.syncPipelines()
+	pipelineService PipelineApiService) (*FlowEngine, error) {
+	if cfg.DataDir != "" {
+		if err := store.CheckVolume(cfg.DataDir); err != nil {
+			if cfg.RequireDataVolume {
+				return nil, err
+			}
+			util.Logger.Warn("data dir is not persisted", "error", err)
+		}
+	}
+	var journal *store.FileStore[journalEntry]
+	if cfg.DataDir != "" {
+		var err error
+		journal, err = store.NewFileStore[journalEntry](filepath.Join(cfg.DataDir, "operations"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	operations := newOperationStore(journal)
+	if err := operations.load(); err != nil {
+		return nil, err
+	}
+	paused, err := openStateStore[lib.PausedPipeline](driver, cfg.DataDir, "paused")
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := openStateStore[scheduledPipeline](driver, cfg.DataDir, "schedules")
+	if err != nil {
+		return nil, err
+	}
+	resources, err := openStateStore[map[string]lib.OperatorResources](driver, cfg.DataDir, "resources")
+	if err != nil {
+		return nil, err
+	}
+	if err = ValidateResourcesConfig(cfg.Resources); err != nil {
+		return nil, err
+	}
+	deploymentModes, err := openStateStore[string](driver, cfg.DataDir, "deployment-modes")
 	if err != nil {
-		util.Logger.Error("failed to sync pipelines", "error", err)
+		return nil, err
+	}
+	if err = ValidateDeploymentMode(cfg.DeploymentMode); err != nil {
+		return nil, err
 	}
-	return f
+	f := &FlowEngine{
+		driver:               driver,
+		parsingService:       parsingService,
+		permissionService:    permissionService,
+		kafak2mqttService:    kafak2mqttService,
+		deviceManagerService: deviceManagerService,
+		pipelineService:      pipelineService,
+		reconcileCfg:         cfg.Reconcile,
+		reconcile:            &reconcileState{},
+		gcCfg:                cfg.GarbageCollection,
+		gc:                   &gcState{},
+		updateCfg:            cfg.Update,
+		locks:                newPipelineLocks(),
+		operations:           operations,
+		paused:               paused,
+		schedules:            schedules,
+		resources:            resources,
+		resourcesCfg:         cfg.Resources,
+		deploymentModes:      deploymentModes,
+		deploymentMode:       cfg.DeploymentMode,
+		queue:                make(chan func(), cfg.Operations.QueueSize),
+		batchConcurrency:     max(cfg.Operations.BatchConcurrency, 1),
+		statusHub:            newStatusHub(),
+	}
+	if fogClient != nil {
+		fogClient.setPausedFilter(f.isPaused)
+		fogClient.setStateListener(f.onFogStatus)
+	}
+	for range max(cfg.Operations.Workers, 1) {
+		go f.runWorker(ctx)
+	}
+	go f.runReconciler(ctx)
+	go f.runGarbageCollector(ctx)
+	go f.runScheduler(ctx)
+	go f.runStatusWatcher(ctx)
+	return f, nil
 }
 
-func (f *FlowEngine) syncPipelines() (err error) {
-	util.Logger.Info("syncing pipelines")
-	pipelines, err := f.pipelineService.GetPipelinesAdmin()
+// openStateStore opens the store name of the state of pipelines. If the driver implements StateStorage, the store is kept
+// by the driver and values still found in dataDir, from before the driver kept them, are moved to it.
+func openStateStore[T any](driver Driver, dataDir, name string) (store.Store[T], error) {
+	local, err := store.Open[T](dataDir, name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	statusTemp, err := f.driver.GetPipelinesStatus()
+	storage, ok := driver.(StateStorage)
+	if !ok {
+		return local, nil
+	}
+	shared := store.NewJSONStore[T](storage.StateStore(name))
+	values, err := local.List()
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	missing, extra := CompareSlicesWithKey(
-		pipelines,
-		statusTemp,
-		func(a pipe.Pipeline) string { return a.Id },
-		func(b lib.PipelineStatus) string { return strings.Replace(b.Name, "pipeline-", "", -1) },
-	)
-	if len(missing) > 0 {
-		util.Logger.Warn("found missing pipelines")
-		for _, item := range missing {
-			item.Image = ""
-			util.Logger.Warn("trying to recreate pipeline", "pipeline", item)
-			//first delete every resource that might still be present
-			err = f.stopOperators(item, "")
-			if err != nil {
-				util.Logger.Error("cannot stop operators", "error", err)
-				return
-			}
-
-			pipeConfig := f.createPipelineConfig(item)
-			pipeConfig.UserId = item.UserId
-			_, err := f.startOperators(item, pipeConfig, "")
-			if err != nil {
-				return fmt.Errorf("failed to start operators: %w", err)
-			}
+	for key, value := range values {
+		// values stored by another instance are newer
+		if _, err = shared.Get(key); errors.Is(err, store.ErrNotFound) {
+			err = shared.Put(key, value)
 		}
-	}
-
-	if len(extra) > 0 {
-		util.Logger.Warn("found extra pipelines")
-		for _, item := range extra {
-			util.Logger.Warn("extra deployment", "deployment", item)
+		if err != nil {
+			return nil, err
+		}
+		if err = local.Delete(key); err != nil {
+			return nil, err
 		}
 	}
-	return
+	return shared, nil
 }
 
 func (f *FlowEngine) StartPipeline(pipelineRequest lib.PipelineRequest, userId string, token string) (pipeline *pipe.Pipeline, err error) {
+	return f.startPipeline(f.newSaga(lib.OperationTypeStart, "", userId), pipelineRequest, userId, token)
+}
+
+func (f *FlowEngine) startPipeline(s *saga, pipelineRequest lib.PipelineRequest, userId string, token string) (pipeline *pipe.Pipeline, err error) {
 	util.Logger.Debug("engine - start pipeline: " + pipelineRequest.Id)
 	pipeline, err = f.setupPipeline(pipelineRequest, userId, token)
 	if err != nil {
+		err = s.fail(err)
+		return
+	}
+	resources, err := f.resolveResources(pipelineRequest, *pipeline)
+	if err != nil {
+		err = s.fail(err)
+		return
+	}
+	deploymentMode, err := f.resolveDeploymentMode(pipelineRequest)
+	if err != nil {
+		err = s.fail(err)
 		return
 	}
 
-	id, err := f.pipelineService.RegisterPipeline(pipeline, userId, token)
+	// the pipeline is locked before the reconciler can see it in the registry
+	err = s.step(stepRegisterPipeline, nil, func() (err error) {
+		pipeline.Id, err = f.locks.register(func() (string, error) {
+			id, err := f.pipelineService.RegisterPipeline(pipeline, userId, token)
+			return id.String(), err
+		})
+		return err
+	}, func() error {
+		return f.pipelineService.DeletePipeline(pipeline.Id, userId, token)
+	})
 	if err != nil {
+		err = s.fail(err)
 		return
 	}
-	pipeline.Id = id.String()
+	defer f.locks.unlock(pipeline.Id)
+	s.setPipelineId(pipeline.Id)
+	s.advance(lib.OperationStateRegistered)
 
 	pipeline.Operators = addPipelineIDToFogTopic(pipeline.Operators, pipeline.Id)
 	pipeConfig := f.createPipelineConfig(*pipeline)
 	pipeConfig.UserId = userId
-	newOperators, err := f.startOperators(*pipeline, pipeConfig, token)
+	pipeConfig.Resources = resources
+	pipeConfig.DeploymentMode = deploymentMode
+	newOperators, err := f.startOperators(s, *pipeline, pipeConfig, token)
 	if err != nil {
-		if delErr := f.pipelineService.DeletePipeline(pipeline.Id, userId, token); delErr != nil {
-			util.Logger.Error("failed to rollback pipeline registration", "error", delErr)
-		}
+		err = s.fail(err)
 		return
 	}
 	pipeline.Operators = newOperators
-	err = f.pipelineService.UpdatePipeline(pipeline, userId, token) //update is needed to set correct fog output topics (with pipeline ID) and instance id for downstream config of fog operators
+	if err = f.storeSchedule(s, pipeline.Id, userId, pipelineRequest.Schedule); err != nil {
+		err = s.fail(err)
+		return
+	}
+	if err = f.storeResources(s, pipeline.Id, resources); err != nil {
+		err = s.fail(err)
+		return
+	}
+	if err = f.storeDeploymentMode(s, pipeline.Id, deploymentMode); err != nil {
+		err = s.fail(err)
+		return
+	}
+	s.setPipeline(*pipeline)
+	//update is needed to set correct fog output topics (with pipeline ID) and instance id for downstream config of fog operators
+	err = s.step(stepUpdateRegistry, nil, func() error {
+		return f.pipelineService.UpdatePipeline(pipeline, userId, token)
+	}, nil)
 	if err != nil {
+		err = s.fail(err)
 		return
 	}
+	s.complete()
 	util.Logger.Debug("started pipeline: "+pipeline.Id, "pipeline", pipeline)
 	return
 }
 
 func (f *FlowEngine) UpdatePipeline(pipelineRequest lib.PipelineRequest, userId string, token string) (pipeline *pipe.Pipeline, err error) {
+	return f.updatePipeline(f.newSaga(lib.OperationTypeUpdate, pipelineRequest.Id, userId), pipelineRequest, userId, token)
+}
+
+func (f *FlowEngine) updatePipeline(s *saga, pipelineRequest lib.PipelineRequest, userId string, token string) (pipeline *pipe.Pipeline, err error) {
 	util.Logger.Debug("engine - update pipeline: " + pipelineRequest.Id)
+	f.locks.lock(pipelineRequest.Id)
+	defer f.locks.unlock(pipelineRequest.Id)
 	oldPipeline, err := f.pipelineService.GetPipeline(pipelineRequest.Id, userId, token)
 	if err != nil {
+		err = s.fail(err)
+		return
+	}
+	if f.isPaused(oldPipeline.Id) {
+		err = s.fail(lib.NewInputError(errors.New("cannot update paused pipeline")))
 		return
 	}
 
 	pipeline, err = f.setupPipeline(pipelineRequest, userId, token)
 	if err != nil {
+		err = s.fail(err)
 		return
 	}
 
-	// If consume all messages is the same, we can reuse the application IDs
-	if pipeline.ConsumeAllMessages == oldPipeline.ConsumeAllMessages {
-		oldAppIds := make(map[string]uuid.UUID)
-		for _, op := range oldPipeline.Operators {
-			oldAppIds[op.Id] = op.ApplicationId
-		}
-		for i := range pipeline.Operators {
-			if appId, exists := oldAppIds[pipeline.Operators[i].Id]; exists {
-				pipeline.Operators[i].ApplicationId = appId
-			}
-		}
+	reuseApplicationIds(pipeline, oldPipeline)
+	resources, err := f.resolveResources(pipelineRequest, *pipeline)
+	if err != nil {
+		err = s.fail(err)
+		return
 	}
-
-	err = f.stopOperators(oldPipeline, token)
+	deploymentMode, err := f.resolveDeploymentMode(pipelineRequest)
 	if err != nil {
-		util.Logger.Error("cannot stop operators", "error", err)
+		err = s.fail(err)
 		return
 	}
 
@@ -174,20 +307,154 @@ func (f *FlowEngine) UpdatePipeline(pipelineRequest lib.PipelineRequest, userId
 	pipeline.Operators = addPipelineIDToFogTopic(pipeline.Operators, pipeline.Id)
 	pipeConfig := f.createPipelineConfig(*pipeline)
 	pipeConfig.UserId = userId
-	newOperators, err := f.startOperators(*pipeline, pipeConfig, token)
+	pipeConfig.Resources = resources
+	pipeConfig.DeploymentMode = deploymentMode
+	versionedDriver, _ := f.blueGreenDriver(pipelineRequest.UpdateStrategy, oldPipeline, *pipeline)
+	newOperators, err := f.updateOperators(s, versionedDriver, oldPipeline, *pipeline, pipeConfig, userId, token)
 	if err != nil {
-		util.Logger.Error("failed to start new operators, attempting to restart old pipeline", "error", err)
-		if _, err = f.startOperators(oldPipeline, f.createPipelineConfig(oldPipeline), token); err != nil {
-			util.Logger.Error("CRITICAL: failed to restart old pipeline", "error", err)
-		}
-		return nil, fmt.Errorf("failed to start operators: %w", err)
+		util.Logger.Error("failed to update operators, restoring old pipeline", "error", err)
+		return nil, fmt.Errorf("failed to update operators: %w", s.fail(err))
 	}
 	pipeline.Operators = newOperators
-	err = f.pipelineService.UpdatePipeline(pipeline, userId, token)
+	if err = f.storeSchedule(s, pipeline.Id, userId, pipelineRequest.Schedule); err != nil {
+		err = s.fail(err)
+		return
+	}
+	if err = f.storeResources(s, pipeline.Id, resources); err != nil {
+		err = s.fail(err)
+		return
+	}
+	if err = f.storeDeploymentMode(s, pipeline.Id, deploymentMode); err != nil {
+		err = s.fail(err)
+		return
+	}
+	s.setPipeline(*pipeline)
+	err = s.step(stepUpdateRegistry, nil, func() error {
+		return f.pipelineService.UpdatePipeline(pipeline, userId, token)
+	}, nil)
+	if err != nil {
+		err = s.fail(err)
+		return
+	}
+	s.complete()
 	util.Logger.Debug("updated pipeline: "+pipeline.Id, "pipeline", pipeline)
 	return
 }
 
+// updateOperators restarts what changed between the old and the new version of a pipeline and keeps everything else running.
+// With a versioned driver the new cloud operators are started next to the old ones and the old ones are only
+// stopped once the new version is healthy. It returns the operators of the new pipeline with their forwarding instances.
+func (f *FlowEngine) updateOperators(s *saga, versionedDriver VersionedDriver, oldPipeline, pipeline pipe.Pipeline, pipeConfig lib.PipelineConfig, userId, token string) (newOperators []pipe.Operator, err error) {
+	diff := diffOperators(oldPipeline, pipeline)
+	if redeployRequired(pipeline, f.createPipelineConfig(oldPipeline), pipeConfig) {
+		diff.redeployCloud = true
+	}
+	_, cloudOperators := seperateOperators(pipeline)
+	_, oldCloudOperators := seperateOperators(oldPipeline)
+	util.Logger.Debug("engine - update operators for pipeline: "+pipeline.Id, "redeployCloud", diff.redeployCloud, "stop", len(diff.stop.Operators), "start", len(diff.start.Operators), "kept", len(diff.kept))
+	blueGreen := versionedDriver != nil && diff.redeployCloud
+
+	var oldVersions []string
+	if blueGreen {
+		oldVersions, err = versionedDriver.GetPipelineVersions(pipeline.Id)
+		if err != nil {
+			return
+		}
+		if len(cloudOperators) > 0 {
+			if err = f.createVersion(s, versionedDriver, pipeline.Id, cloudOperators, pipeConfig); err != nil {
+				return
+			}
+		}
+	}
+
+	err = s.step(stepStopOldOperators, diff.stepData(), func() error {
+		if blueGreen {
+			for _, version := range oldVersions {
+				if err := versionedDriver.DeleteOperatorsVersion(oldPipeline.Id, version, oldCloudOperators); err != nil {
+					return err
+				}
+			}
+		} else if diff.redeployCloud && len(oldCloudOperators) > 0 {
+			if err := f.driver.DeleteOperators(oldPipeline.Id, oldCloudOperators); err != nil {
+				//ignore error if operator was not found
+				var notFoundErr *lib.NotFoundError
+				if ok := errors.As(err, &notFoundErr); !ok {
+					return err
+				}
+			}
+		}
+		return f.stopForwarding(diff.stop, token)
+	}, func() error {
+		return f.restoreChangedOperators(oldPipeline, diff, userId, token)
+	})
+	if err != nil {
+		util.Logger.Error("cannot stop operators", "error", err)
+		return
+	}
+
+	if diff.redeployCloud && !blueGreen && len(cloudOperators) > 0 {
+		if err = f.createCloudOperators(s, pipeline.Id, cloudOperators, pipeConfig); err != nil {
+			return
+		}
+	}
+	started, err := f.startForwarding(s, diff.start, pipeConfig, token)
+	if err != nil {
+		return
+	}
+	return mergeOperators(pipeline.Operators, diff.kept, started), nil
+}
+
+// restoreOperators starts the operators of a pipeline as stored in the registry again
+// and updates the registry with the newly created forwarding instances.
+func (f *FlowEngine) restoreOperators(pipeline pipe.Pipeline, userId, token string) error {
+	return f.restoreChangedOperators(pipeline, operatorDiff{redeployCloud: true, stop: pipeline}, userId, token)
+}
+
+// restoreChangedOperators starts what an update stopped of the pipeline as stored in the registry again
+// and updates the registry with the newly created forwarding instances.
+func (f *FlowEngine) restoreChangedOperators(pipeline pipe.Pipeline, diff operatorDiff, userId, token string) error {
+	s := f.newSaga(lib.OperationTypeRestore, pipeline.Id, userId)
+	pipeConfig := f.createPipelineConfig(pipeline)
+	pipeConfig.UserId = pipeline.UserId
+	_, cloudOperators := seperateOperators(pipeline)
+	if diff.redeployCloud && len(cloudOperators) > 0 {
+		if err := f.createCloudOperators(s, pipeline.Id, cloudOperators, pipeConfig); err != nil {
+			return s.fail(err)
+		}
+	}
+	started, err := f.startForwarding(s, diff.stop, pipeConfig, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	pipeline.Operators = mergeOperators(pipeline.Operators, started)
+	s.setPipeline(pipeline)
+	err = s.step(stepUpdateRegistry, nil, func() error {
+		return f.pipelineService.UpdatePipeline(&pipeline, userId, token)
+	}, nil)
+	if err != nil {
+		return s.fail(err)
+	}
+	s.complete()
+	return nil
+}
+
+// reuseApplicationIds keeps the application IDs of the old pipeline's operators,
+// if consume all messages is the same, so that consumers continue at their offsets.
+func reuseApplicationIds(pipeline *pipe.Pipeline, oldPipeline pipe.Pipeline) {
+	if pipeline.ConsumeAllMessages != oldPipeline.ConsumeAllMessages {
+		return
+	}
+	oldAppIds := make(map[string]uuid.UUID)
+	for _, op := range oldPipeline.Operators {
+		oldAppIds[op.Id] = op.ApplicationId
+	}
+	for i := range pipeline.Operators {
+		if appId, exists := oldAppIds[pipeline.Operators[i].Id]; exists {
+			pipeline.Operators[i].ApplicationId = appId
+		}
+	}
+}
+
 func (f *FlowEngine) setupPipeline(pipelineRequest lib.PipelineRequest, userId, token string) (*pipe.Pipeline, error) {
 	parsedPipeline, err := f.parsingService.GetPipeline(pipelineRequest.FlowId, userId, token)
 	if err != nil {
@@ -211,45 +478,88 @@ func (f *FlowEngine) setupPipeline(pipelineRequest lib.PipelineRequest, userId,
 }
 
 func (f *FlowEngine) DeletePipeline(id string, userId string, token string) (err error) {
+	return f.deletePipeline(f.newSaga(lib.OperationTypeDelete, id, userId), id, userId, token)
+}
+
+func (f *FlowEngine) deletePipeline(s *saga, id string, userId string, token string) (err error) {
 	util.Logger.Debug("engine - delete pipeline: " + id)
+	f.locks.lock(id)
+	defer f.locks.unlock(id)
 	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
 	if err != nil {
-		return
+		return s.fail(err)
 	}
-	err = f.stopOperators(pipeline, token)
-	if err != nil {
-		if !k8apierrors.IsNotFound(err) {
-			return
+	err = s.step(stepStopOperators, nil, func() error {
+		err := f.stopOperators(pipeline, token)
+		if err != nil {
+			if !k8apierrors.IsNotFound(err) {
+				return err
+			}
+		} else {
+			util.Logger.Debug("removed all operators for pipeline: " + id)
 		}
-	} else {
-		util.Logger.Debug("removed all operators for pipeline: " + id)
+		return nil
+	}, nil)
+	if err != nil {
+		return s.fail(err)
 	}
-	err = f.pipelineService.DeletePipeline(id, userId, token)
+	err = s.step(stepDeleteFromRegistry, nil, func() error {
+		return f.pipelineService.DeletePipeline(id, userId, token)
+	}, nil)
 	if err != nil {
-		return
+		return s.fail(err)
 	}
+	f.forgetPipelineState(id)
+	s.complete()
 	return
 }
 
 func (f *FlowEngine) GetPipelineStatus(id, userId, token string) (status lib.PipelineStatus, err error) {
-	_, err = f.pipelineService.GetPipeline(id, userId, token)
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
 	if err != nil {
 		return
 	}
-	status, err = f.driver.GetPipelineStatus(id)
+	if f.isPaused(id) {
+		status = f.pausedStatus(id)
+		status.Name = ""
+		return
+	}
+	// a pipeline of local operators only has no deployments
+	if _, cloudOperators := seperateOperators(pipeline); len(cloudOperators) > 0 {
+		status, err = f.driver.GetPipelineStatus(id)
+		if err != nil {
+			return
+		}
+	}
+	f.addPipelineState(&status, pipeline)
 	return
 }
 
 func (f *FlowEngine) GetPipelinesStatus(ids []string, userId, token string) (status []lib.PipelineStatus, err error) {
 	statusTemp, err := f.driver.GetPipelinesStatus()
+	if err != nil {
+		return
+	}
 	pipes, err := f.pipelineService.GetPipelines(userId, token)
 	if err != nil {
 		return
 	}
 	for _, stat := range statusTemp {
 		idx := slices.IndexFunc(pipes, func(p pipe.Pipeline) bool { return "pipeline-"+p.Id == stat.Name })
-		if idx != -1 {
+		if idx != -1 && !f.isPaused(pipes[idx].Id) {
 			stat.Name = strings.Replace(stat.Name, "pipeline-", "", -1)
+			f.addPipelineState(&stat, pipes[idx])
+			status = append(status, stat)
+		}
+	}
+	for _, p := range pipes {
+		if f.isPaused(p.Id) {
+			status = append(status, f.pausedStatus(p.Id))
+			continue
+		}
+		if _, cloudOperators := seperateOperators(p); len(cloudOperators) == 0 && !slices.ContainsFunc(status, func(s lib.PipelineStatus) bool { return s.Name == p.Id }) {
+			stat := lib.PipelineStatus{Name: p.Id}
+			f.addPipelineState(&stat, p)
 			status = append(status, stat)
 		}
 	}
@@ -370,7 +680,16 @@ func (f *FlowEngine) stopOperators(pipeline pipe.Pipeline, token string) error {
 				return err
 			}
 		}
-		err = f.disableCloudToFogForwarding(cloudOperators, pipeline.Id, pipeline.UserId, token)
+	}
+	return f.stopForwarding(pipeline, token)
+}
+
+// stopForwarding removes the forwarding of the cloud operators and stops the local operators of a pipeline.
+func (f *FlowEngine) stopForwarding(pipeline pipe.Pipeline, token string) error {
+	localOperators, cloudOperators := seperateOperators(pipeline)
+
+	if len(cloudOperators) > 0 {
+		err := f.disableCloudToFogForwarding(cloudOperators, pipeline.Id, pipeline.UserId, token)
 		if err != nil {
 			util.Logger.Error("cannot disable cloud2fog forwarding", "error", err)
 			return err
@@ -394,57 +713,110 @@ func (f *FlowEngine) stopOperators(pipeline pipe.Pipeline, token string) error {
 	return nil
 }
 
-func (f *FlowEngine) startOperators(pipeline pipe.Pipeline, pipeConfig lib.PipelineConfig, token string) (newOperators []pipe.Operator, err error) {
-	localOperators, cloudOperators := seperateOperators(pipeline)
+// startOperators creates the cloud operators and starts the local operators of a pipeline,
+// every side effect is recorded as a step of s.
+func (f *FlowEngine) startOperators(s *saga, pipeline pipe.Pipeline, pipeConfig lib.PipelineConfig, token string) (newOperators []pipe.Operator, err error) {
+	_, cloudOperators := seperateOperators(pipeline)
 
 	if len(cloudOperators) > 0 {
-		util.Logger.Debug("try to start cloud operators")
-		err = retry(6, 10*time.Second, func() (err error) {
+		if err = f.createCloudOperators(s, pipeline.Id, cloudOperators, pipeConfig); err != nil {
+			return
+		}
+	}
+	return f.startForwarding(s, pipeline, pipeConfig, token)
+}
+
+// createCloudOperators creates the deployment of the cloud operators as a step of s.
+func (f *FlowEngine) createCloudOperators(s *saga, pipelineId string, cloudOperators []pipe.Operator, pipeConfig lib.PipelineConfig) error {
+	util.Logger.Debug("try to start cloud operators")
+	err := s.step(stepCreateCloudOperators, nil, func() error {
+		return retry(6, 10*time.Second, func() (err error) {
 			return f.driver.CreateOperators(
-				pipeline.Id,
+				pipelineId,
 				cloudOperators,
 				pipeConfig,
 			)
 		})
-		if err != nil {
-			util.Logger.Error("cannot start cloud operators", "error", err)
+	}, func() error {
+		return f.driver.DeleteOperators(pipelineId, cloudOperators)
+	})
+	if err != nil {
+		util.Logger.Error("cannot start cloud operators", "error", err)
+		return err
+	}
+	util.Logger.Debug("engine - successfully started cloud operators - " + pipelineId)
+	s.advance(lib.OperationStateDriverCreated)
+	return nil
+}
+
+// startForwarding enables the forwarding of the already created cloud operators and starts the local operators of a pipeline,
+// every side effect is recorded as a step of s.
+func (f *FlowEngine) startForwarding(s *saga, pipeline pipe.Pipeline, pipeConfig lib.PipelineConfig, token string) (newOperators []pipe.Operator, err error) {
+	localOperators, cloudOperators := seperateOperators(pipeline)
+
+	if len(cloudOperators) > 0 {
+		cloudOperatorsWithDownstreamID, err2 := f.enableCloudToFogForwarding(s, cloudOperators, pipeline.Id, pipeline.UserId, token)
+		if err2 != nil {
+			util.Logger.Error("cannot enable cloud2fog forwarding", "error", err2)
+			err = err2
 			return
-		} else {
-			util.Logger.Debug("engine - successfully started cloud operators - " + pipeline.Id)
-			cloudOperatorsWithDownstreamID, err2 := f.enableCloudToFogForwarding(cloudOperators, pipeline.Id, pipeline.UserId, token)
-			if err2 != nil {
-				util.Logger.Error("cannot enable cloud2fog forwarding", "error", err2)
-				err = err2
-				return
-			}
-			newOperators = append(newOperators, cloudOperatorsWithDownstreamID...)
 		}
+		newOperators = append(newOperators, cloudOperatorsWithDownstreamID...)
 	}
 	if len(localOperators) > 0 {
 		for _, operator := range localOperators {
 			util.Logger.Debug("try to start local operator: " + operator.Name + " for pipeline: " + pipeline.Id)
-			err = startFogOperator(operator, pipeConfig, pipeline.UserId)
+			err = s.step(stepStartLocalOperator, map[string]string{
+				"operatorId":     operator.Id,
+				"baseOperatorId": operator.OperatorId,
+				"userId":         pipeline.UserId,
+			}, func() error {
+				return startFogOperator(operator, pipeConfig, pipeline.UserId)
+			}, func() error {
+				return stopFogOperator(pipeline.Id, operator, pipeline.UserId)
+			})
 			if err != nil {
 				util.Logger.Error("cannot start local operator", "error", err, "operator", operator)
 				return
 			}
 			util.Logger.Debug("engine - successfully started local operator: " + operator.Name + " for pipeline: " + pipeline.Id)
 
-			err = f.enableFogToCloudForwarding(operator, pipeline.Id, pipeline.UserId)
-			if err != nil {
-				return
+			if operator.UpstreamConfig.Enabled {
+				err = s.step(stepEnableFogToCloud, map[string]string{
+					"operatorId":  operator.Id,
+					"outputTopic": operator.OutputTopic,
+					"userId":      pipeline.UserId,
+				}, func() error {
+					return f.enableFogToCloudForwarding(operator, pipeline.Id, pipeline.UserId)
+				}, func() error {
+					return f.disableFogToCloudForwarding(operator, pipeline.Id, pipeline.UserId, token)
+				})
+				if err != nil {
+					return
+				}
 			}
 			newOperators = append(newOperators, operator)
 		}
 	}
+	s.advance(lib.OperationStateForwardingEnabled)
 	return
 }
 
-func (f *FlowEngine) enableCloudToFogForwarding(operators []pipe.Operator, pipelineID, userID, token string) (newOperators []pipe.Operator, err error) {
+func (f *FlowEngine) enableCloudToFogForwarding(s *saga, operators []pipe.Operator, pipelineID, userID, token string) (newOperators []pipe.Operator, err error) {
 	for _, operator := range operators {
 		if operator.DownstreamConfig.Enabled {
 			util.Logger.Debug("Try to enable Cloud2Fog Forwarding for operator: " + operator.Id)
-			createdInstance, err := f.kafak2mqttService.StartOperatorInstance(operator.Name, operator.Id, pipelineID, userID, token)
+			var createdInstance kafka2mqtt_api.Instance
+			data := map[string]string{"operatorId": operator.Id}
+			err = s.step(stepEnableCloudToFog, data, func() (err error) {
+				createdInstance, err = f.kafak2mqttService.StartOperatorInstance(operator.Name, operator.Id, pipelineID, userID, token)
+				if err == nil {
+					data["instanceId"] = createdInstance.Id
+				}
+				return
+			}, func() error {
+				return f.kafak2mqttService.RemoveInstance(createdInstance.Id, pipelineID, userID, token)
+			})
 			if err != nil {
 				util.Logger.Error("cannot enable cloud2fog forwarding", "error", err, "operator", operator)
 				return []pipe.Operator{}, err
@@ -461,15 +833,13 @@ func (f *FlowEngine) enableFogToCloudForwarding(operator pipe.Operator, _, userI
 	if operator.UpstreamConfig.Enabled {
 		util.Logger.Debug("Try to enable Fog2Cloud Forwarding for operator: " + operator.Id)
 
-		command := &upstreamLib.UpstreamControlMessage{
-			OperatorOutputTopic: operator.OutputTopic,
-		}
-		message, err := json.Marshal(command)
+		command := upstreamEnableMessage(operator, userID)
+		message, err := json.Marshal(command.Payload)
 		if err != nil {
 			util.Logger.Error("cannot unmarshal enable fog2cloud message for operator: "+operator.Name+" - "+operator.Id, "error", err)
 			return err
 		}
-		topic := upstreamLib.GetUpstreamEnableCloudTopic(userID)
+		topic := command.Topic
 		util.Logger.Debug("try to publish enable forwarding command for operator: " + operator.Name + " - " + operator.Id + " to topic: " + topic)
 		err = publishMessage(topic, string(message))
 		if err != nil {
@@ -505,16 +875,14 @@ func (f *FlowEngine) disableCloudToFogForwarding(operators []pipe.Operator, pipe
 
 func (f *FlowEngine) disableFogToCloudForwarding(operator pipe.Operator, _, userID, _ string) error {
 	if operator.UpstreamConfig.Enabled {
-		command := &upstreamLib.UpstreamControlMessage{
-			OperatorOutputTopic: operator.OutputTopic,
-		}
-		message, err := json.Marshal(command)
+		command := upstreamDisableMessage(operator, userID)
+		message, err := json.Marshal(command.Payload)
 		if err != nil {
 			util.Logger.Error("cannot unmarshal disable fog2cloud message for operator: "+operator.Name+" - "+operator.Id, "error", err)
 			return err
 		}
 		util.Logger.Debug("try to publish disable forwarding command for operator: " + operator.Name + " - " + operator.Id)
-		err = publishMessage(upstreamLib.GetUpstreamDisableCloudTopic(userID), string(message))
+		err = publishMessage(command.Topic, string(message))
 		if err != nil {
 			util.Logger.Error("cannot publish disable fog2cloud message for operator: "+operator.Name+" - "+operator.Id, "error", err)
 		}
@@ -524,6 +892,22 @@ func (f *FlowEngine) disableFogToCloudForwarding(operator pipe.Operator, _, user
 	return nil
 }
 
+// upstreamEnableMessage builds the control message which enables forwarding of a local operator's output to the cloud.
+func upstreamEnableMessage(operator pipe.Operator, userID string) lib.PlannedMessage {
+	return lib.PlannedMessage{
+		Topic:   upstreamLib.GetUpstreamEnableCloudTopic(userID),
+		Payload: &upstreamLib.UpstreamControlMessage{OperatorOutputTopic: operator.OutputTopic},
+	}
+}
+
+// upstreamDisableMessage builds the control message which disables forwarding of a local operator's output to the cloud.
+func upstreamDisableMessage(operator pipe.Operator, userID string) lib.PlannedMessage {
+	return lib.PlannedMessage{
+		Topic:   upstreamLib.GetUpstreamDisableCloudTopic(userID),
+		Payload: &upstreamLib.UpstreamControlMessage{OperatorOutputTopic: operator.OutputTopic},
+	}
+}
+
 func (f *FlowEngine) createPipelineConfig(pipeline pipe.Pipeline) lib.PipelineConfig {
 	var pipeConfig = lib.PipelineConfig{
 		WindowTime:     pipeline.WindowTime,
@@ -532,6 +916,9 @@ func (f *FlowEngine) createPipelineConfig(pipeline pipe.Pipeline) lib.PipelineCo
 		ConsumerOffset: "latest",
 		Metrics:        true, // always enable metrics SNRGY-3068 pipeline.Metrics,
 		PipelineId:     pipeline.Id,
+		Resources:      f.getResources(pipeline.Id),
+		MaxResources:   lib.OperatorResources{CpuLimit: f.resourcesCfg.MaxCpu, MemoryLimit: f.resourcesCfg.MaxMemory},
+		DeploymentMode: f.getDeploymentMode(pipeline.Id),
 	}
 	if pipeline.ConsumeAllMessages {
 		pipeConfig.ConsumerOffset = "earliest"
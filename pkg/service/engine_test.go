@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+)
+
+func TestOpenStateStore(t *testing.T) {
+	dir := t.TempDir()
+	local, err := store.Open[string](dir, "deployment-modes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = local.Put("moved", lib.DeploymentModeOperator)
+	_ = local.Put("stale", lib.DeploymentModeOperator)
+	driver := &fakeStateDriver{fakeDriver: newFakeDriver()}
+	_ = driver.StateStore("deployment-modes").Put("stale", json.RawMessage(`"pipeline"`))
+
+	modes, err := openStateStore[string](driver, dir, "deployment-modes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode, _ := modes.Get("moved"); mode != lib.DeploymentModeOperator {
+		t.Errorf("expected local value to be moved to the driver, got %q", mode)
+	}
+	if mode, _ := modes.Get("stale"); mode != lib.DeploymentModePipeline {
+		t.Errorf("expected value of the driver to be kept, got %q", mode)
+	}
+	if values, _ := local.List(); len(values) != 0 {
+		t.Errorf("expected local values to be removed, got %v", values)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
 	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
@@ -33,10 +35,35 @@ import (
 
 type FogClient struct {
 	pipelineService PipelineApiService
+	mu              sync.RWMutex
+	isPaused        func(pipelineId string) bool
+	states          map[string]map[string]fogOperatorState // by user ID and operator ID
+	mastersSeen     map[string]time.Time                   // last operator sync request of the fog master, by user ID
+	onStateChange   func(userID, pipelineID string)
 }
 
 func NewFogClient(pipelineService PipelineApiService) *FogClient {
-	return &FogClient{pipelineService}
+	return &FogClient{pipelineService: pipelineService}
+}
+
+// setPausedFilter sets the check used to leave the operators of paused pipelines out of sync responses.
+func (f *FogClient) setPausedFilter(isPaused func(pipelineId string) bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.isPaused = isPaused
+}
+
+// setStateListener sets the function called whenever the state of a local operator changed.
+func (f *FogClient) setStateListener(onStateChange func(userID, pipelineID string)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onStateChange = onStateChange
+}
+
+func (f *FogClient) paused(pipelineId string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.isPaused != nil && f.isPaused(pipelineId)
 }
 
 func (f *FogClient) processMessage(message MQTT.Message) {
@@ -45,6 +72,7 @@ func (f *FogClient) processMessage(message MQTT.Message) {
 
 	if strings.HasSuffix(topic, "/operator/control/sync/request") {
 		userID := operatorLib.GetUserIDFromOperatorControlSyncTopic(topic)
+		f.masterSynced(userID)
 		f.sendActiveOperators(userID, "")
 	}
 
@@ -61,6 +89,9 @@ func (f *FogClient) sendActiveOperators(userID string, token string) {
 	}
 	var startCommands []operatorLib.StartOperatorControlCommand
 	for _, pipeline := range pipelines {
+		if f.paused(pipeline.Id) {
+			continue
+		}
 		for _, operator := range pipeline.Operators {
 			if operator.DeploymentType == "local" {
 				inputTopics := convertInputTopics(operator.InputTopics)
@@ -89,6 +120,9 @@ func (f *FogClient) sendTopicsWithEnabledForward(userID string, token string) {
 
 	var topics []string
 	for _, pipeline := range pipelines {
+		if f.paused(pipeline.Id) {
+			continue
+		}
 		for _, operator := range pipeline.Operators {
 			if operator.DeploymentType == "local" {
 				if operator.UpstreamConfig.Enabled {
@@ -144,44 +178,66 @@ func GenerateFogOperatorStartCommand(operator pipe.Operator, pipelineID string,
 	}
 }
 
-func startFogOperator(operator pipe.Operator, pipelineConfig lib.PipelineConfig, userID string) error {
-	inputTopics := convertInputTopics(operator.InputTopics)
+// fogOperatorStartMessage builds the control message which starts a local operator on a fog agent.
+func fogOperatorStartMessage(operator pipe.Operator, pipelineId string, userID string) lib.PlannedMessage {
+	return lib.PlannedMessage{
+		Topic:   operatorLib.GetStartOperatorCloudTopic(userID),
+		Payload: GenerateFogOperatorStartCommand(operator, pipelineId, convertInputTopics(operator.InputTopics)),
+	}
+}
+
+// fogOperatorStopMessage builds the control message which stops a local operator on a fog agent.
+func fogOperatorStopMessage(pipelineId string, operator pipe.Operator, userID string) lib.PlannedMessage {
+	return lib.PlannedMessage{
+		Topic: operatorLib.GetStopOperatorCloudTopic(userID),
+		Payload: &operatorLib.StopOperatorControlCommand{
+			OperatorIDs: operatorLib.OperatorIDs{
+				OperatorId:     operator.Id,
+				PipelineId:     pipelineId,
+				BaseOperatorId: operator.OperatorId,
+			},
+		},
+	}
+}
 
-	command := GenerateFogOperatorStartCommand(operator, pipelineConfig.PipelineId, inputTopics)
-	out, err := json.Marshal(command)
+func startFogOperator(operator pipe.Operator, pipelineConfig lib.PipelineConfig, userID string) error {
+	message := fogOperatorStartMessage(operator, pipelineConfig.PipelineId, userID)
+	out, err := json.Marshal(message.Payload)
 	if err != nil {
 		return err
 	}
-	controlTopic := operatorLib.GetStartOperatorCloudTopic(userID)
-	util.Logger.Debug("publish start command for operator", "operator", operator, "topic", controlTopic)
-	err = publishMessage(controlTopic, string(out))
+	util.Logger.Debug("publish start command for operator", "operator", operator, "topic", message.Topic)
+	err = publishMessage(message.Topic, string(out))
 	if err != nil {
 		util.Logger.Error("cannot publish start command for operator", "error", err, "operator", operator)
-		return err
 	}
-	return nil
+	if fogClient != nil {
+		state := fogOperatorState{PipelineId: pipelineConfig.PipelineId, State: lib.FogOperatorStateUnknown}
+		if err != nil {
+			state.State = lib.FogOperatorStateFailed
+			state.Error = err.Error()
+		}
+		fogClient.setOperatorState(userID, operator.Id, state)
+	}
+	return err
 }
 
 func stopFogOperator(pipelineId string, operator pipe.Operator, userID string) error {
-	command := &operatorLib.StopOperatorControlCommand{
-		OperatorIDs: operatorLib.OperatorIDs{
-			OperatorId:     operator.Id,
-			PipelineId:     pipelineId,
-			BaseOperatorId: operator.OperatorId,
-		},
-	}
-	out, err := json.Marshal(command)
+	message := fogOperatorStopMessage(pipelineId, operator, userID)
+	out, err := json.Marshal(message.Payload)
 	if err != nil {
 		util.Logger.Error("cannot unmarshal stop command for operator", "error", err, "operator", operator)
 		return err
 	}
 
-	controlTopic := operatorLib.GetStopOperatorCloudTopic(userID)
-	util.Logger.Debug("publish stop command for operator", "operator", operator, "topic", controlTopic)
-	err = publishMessage(controlTopic, string(out))
+	util.Logger.Debug("publish stop command for operator", "operator", operator, "topic", message.Topic)
+	err = publishMessage(message.Topic, string(out))
 	if err != nil {
 		util.Logger.Error("cannot publish stop command for operator", "error", err, "operator", operator)
 		return err
 	}
+	if fogClient != nil {
+		fogClient.forgetOperator(userID, operator.Id)
+	}
 	return nil
 }
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// Fog masters do not report the state of single operators, analytics-fog-lib only defines the control and sync topics.
+// A local operator is therefore failed if its start command could not be published and unknown otherwise.
+// It was last seen when the fog master of its user last requested its operators, as the master then receives
+// the start commands of all active operators.
+type fogOperatorState struct {
+	PipelineId string
+	State      string
+	Error      string
+}
+
+func (f *FogClient) setOperatorState(userID, operatorID string, state fogOperatorState) {
+	f.mu.Lock()
+	if f.states == nil {
+		f.states = make(map[string]map[string]fogOperatorState)
+	}
+	if f.states[userID] == nil {
+		f.states[userID] = make(map[string]fogOperatorState)
+	}
+	f.states[userID][operatorID] = state
+	onStateChange := f.onStateChange
+	f.mu.Unlock()
+	if onStateChange != nil {
+		onStateChange(userID, state.PipelineId)
+	}
+}
+
+func (f *FogClient) forgetOperator(userID, operatorID string) {
+	f.mu.Lock()
+	state, ok := f.states[userID][operatorID]
+	delete(f.states[userID], operatorID)
+	if len(f.states[userID]) == 0 {
+		delete(f.states, userID)
+	}
+	onStateChange := f.onStateChange
+	f.mu.Unlock()
+	if ok && onStateChange != nil {
+		onStateChange(userID, state.PipelineId)
+	}
+}
+
+// masterSynced records that the fog master of userID requested its operators.
+func (f *FogClient) masterSynced(userID string) {
+	f.mu.Lock()
+	if f.mastersSeen == nil {
+		f.mastersSeen = make(map[string]time.Time)
+	}
+	f.mastersSeen[userID] = time.Now().UTC()
+	var pipelineIDs []string
+	for _, state := range f.states[userID] {
+		pipelineIDs = append(pipelineIDs, state.PipelineId)
+	}
+	onStateChange := f.onStateChange
+	f.mu.Unlock()
+	if onStateChange != nil {
+		for _, pipelineID := range pipelineIDs {
+			onStateChange(userID, pipelineID)
+		}
+	}
+}
+
+// operatorStatus returns the state of a local operator as far as it is known to the engine.
+func (f *FogClient) operatorStatus(userID string, operator pipe.Operator) lib.FogOperatorStatus {
+	status := lib.FogOperatorStatus{OperatorId: operator.Id, Name: operator.Name, State: lib.FogOperatorStateUnknown}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if seen, ok := f.mastersSeen[userID]; ok {
+		status.LastSeen = &seen
+	}
+	if state, ok := f.states[userID][operator.Id]; ok {
+		status.State = state.State
+		status.Error = state.Error
+	}
+	return status
+}
+
+// fogOperatorStatus returns the state of a local operator, which is unknown while not connected to the broker.
+func fogOperatorStatus(userID string, operator pipe.Operator) lib.FogOperatorStatus {
+	if fogClient == nil {
+		return lib.FogOperatorStatus{OperatorId: operator.Id, Name: operator.Name, State: lib.FogOperatorStateUnknown}
+	}
+	return fogClient.operatorStatus(userID, operator)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFogClient_operatorStatus(t *testing.T) {
+	util.InitStructLogger("error")
+	f := NewFogClient(nil)
+	var changed []string
+	f.setStateListener(func(_, pipelineID string) { changed = append(changed, pipelineID) })
+	operator := pipe.Operator{Id: "op", Name: "local-op"}
+
+	if status := f.operatorStatus("user", operator); status.State != lib.FogOperatorStateUnknown || status.LastSeen != nil {
+		t.Errorf("expected unknown state without fog master, got %+v", status)
+	}
+	f.setOperatorState("user", "op", fogOperatorState{PipelineId: "pid", State: lib.FogOperatorStateFailed, Error: "not connected"})
+	if status := f.operatorStatus("user", operator); status.State != lib.FogOperatorStateFailed || status.Error != "not connected" {
+		t.Errorf("expected failed start command, got %+v", status)
+	}
+	f.setOperatorState("user", "op", fogOperatorState{PipelineId: "pid", State: lib.FogOperatorStateUnknown})
+	f.masterSynced("user")
+	if status := f.operatorStatus("user", operator); status.State != lib.FogOperatorStateUnknown || status.Error != "" || status.LastSeen == nil {
+		t.Errorf("expected unknown state seen by fog master, got %+v", status)
+	}
+	if status := f.operatorStatus("other", operator); status.LastSeen != nil {
+		t.Errorf("expected fog master of other user not to be seen, got %+v", status)
+	}
+	f.forgetOperator("user", "op")
+	if len(changed) != 4 {
+		t.Errorf("expected 4 state changes, got %v", changed)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+)
+
+type gcState struct {
+	mu         sync.Mutex
+	running    sync.Mutex
+	lastResult *lib.GarbageCollectionResult
+}
+
+// runGarbageCollector collects garbage in the configured interval until ctx is cancelled.
+func (f *FlowEngine) runGarbageCollector(ctx context.Context) {
+	if f.gcCfg.Interval <= 0 {
+		util.Logger.Info("scheduled garbage collection disabled")
+		return
+	}
+	ticker := time.NewTicker(f.gcCfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			util.Logger.Info("stopping garbage collector")
+			return
+		case <-ticker.C:
+			f.CollectGarbage(f.gcCfg.DryRun)
+		}
+	}
+}
+
+// CollectGarbage determines all driver resources whose pipeline is not known to the pipeline registry.
+// Unless dryRun is set, the orphaned resources are deleted.
+func (f *FlowEngine) CollectGarbage(dryRun bool) (result lib.GarbageCollectionResult) {
+	f.gc.running.Lock()
+	defer f.gc.running.Unlock()
+
+	result.DryRun = dryRun
+	result.StartedAt = time.Now().UTC()
+	defer func() {
+		result.FinishedAt = time.Now().UTC()
+		f.gc.mu.Lock()
+		f.gc.lastResult = &result
+		f.gc.mu.Unlock()
+	}()
+
+	util.Logger.Info("collecting garbage", "dryRun", dryRun)
+	// resources are listed before the registry, so that every resource of a pipeline started meanwhile has a registry entry
+	resources, err := f.driver.GetPipelineResources()
+	if err != nil {
+		util.Logger.Error("cannot get pipeline resources for garbage collection", "error", err)
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+	pipelines, err := f.locks.listRegistered(f.pipelineService.GetPipelinesAdmin)
+	if err != nil {
+		util.Logger.Error("cannot get pipelines for garbage collection", "error", err)
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+
+	known := make(map[string]bool, len(pipelines))
+	for _, pipeline := range pipelines {
+		known[pipeline.Id] = true
+	}
+	// pipelines with an operation in progress are skipped, the others stay locked until their resources are removed
+	locked := make(map[string]bool)
+	defer func() {
+		for id := range locked {
+			f.locks.unlock(id)
+		}
+	}()
+	for _, resource := range resources {
+		if known[resource.PipelineId] {
+			continue
+		}
+		if !locked[resource.PipelineId] {
+			if !f.locks.tryLock(resource.PipelineId) {
+				continue
+			}
+			locked[resource.PipelineId] = true
+		}
+		result.Orphans = append(result.Orphans, resource)
+	}
+	if dryRun || len(result.Orphans) == 0 {
+		return
+	}
+	if len(pipelines) == 0 {
+		// an empty registry response is more likely an error than a valid state, never wipe everything
+		util.Logger.Warn("pipeline registry returned no pipelines, skipping removal of orphaned resources")
+		result.Errors = append(result.Errors, "pipeline registry returned no pipelines, nothing removed")
+		return
+	}
+	for _, resource := range result.Orphans {
+		util.Logger.Warn("removing orphaned resource", "resource", resource)
+		if err = f.driver.DeletePipelineResource(resource); err != nil {
+			util.Logger.Error("cannot remove orphaned resource", "error", err, "resource", resource)
+			result.Errors = append(result.Errors, resource.Kind+" "+resource.Name+": "+err.Error())
+			continue
+		}
+		result.Removed = append(result.Removed, resource)
+	}
+	return
+}
+
+// GetLastGarbageCollectionResult returns the result of the most recent garbage collection run.
+func (f *FlowEngine) GetLastGarbageCollectionResult() (result lib.GarbageCollectionResult, err error) {
+	f.gc.mu.Lock()
+	defer f.gc.mu.Unlock()
+	if f.gc.lastResult == nil {
+		err = lib.NewNotFoundError(errors.New("no garbage collection has run yet"))
+		return
+	}
+	return *f.gc.lastResult, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_CollectGarbage(t *testing.T) {
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid"}}}
+	driver := newFakeDriver()
+	driver.resources = []lib.PipelineResource{
+		{Kind: lib.ResourceKindDeployment, Name: "pipeline-pid", PipelineId: "pid"},
+		{Kind: lib.ResourceKindDeployment, Name: "pipeline-new", PipelineId: "new"},
+		{Kind: lib.ResourceKindDeployment, Name: "pipeline-orphan", PipelineId: "orphan"},
+		{Kind: lib.ResourceKindDeployment, Name: "pipeline-busy", PipelineId: "busy"},
+	}
+	// a pipeline is registered and started while the resources are listed
+	driver.onGetPipelineResources = func() {
+		pipelines.mu.Lock()
+		defer pipelines.mu.Unlock()
+		pipelines.pipelines = append(pipelines.pipelines, pipe.Pipeline{Id: "new"})
+	}
+	f := newTestEngine(driver, pipelines)
+	f.locks.lock("busy")
+
+	result := f.CollectGarbage(false)
+	if len(result.Errors) != 0 {
+		t.Fatal(result.Errors)
+	}
+	if len(driver.deletedResources) != 1 || driver.deletedResources[0] != "pipeline-orphan" {
+		t.Errorf("unexpected deleted resources %v", driver.deletedResources)
+	}
+	if f.locks.tryLock("busy") {
+		t.Error("expected lock of pipeline with operation in progress to be kept")
+	}
+	if !f.locks.tryLock("orphan") {
+		t.Error("expected lock of orphaned pipeline to be released")
+	}
+}
@@ -17,7 +17,12 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+
 	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
 	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
 	"github.com/SENERGY-Platform/models/go/models"
 	"github.com/google/uuid"
@@ -28,14 +33,52 @@ import (
 
 type Driver interface {
 	CreateOperators(pipelineId string, input []pipe.Operator, pipelineConfig lib.PipelineConfig) error
+	PlanOperators(pipelineId string, input []pipe.Operator, pipelineConfig lib.PipelineConfig) ([]lib.PlannedResource, error)
 	/*
 		DeleteOperator deletes an operator in the given pipeline
 		Deprecated: Use DeleteOperators instead.
 	*/
 	DeleteOperator(pipelineId string, input pipe.Operator) error
 	DeleteOperators(pipelineId string, inputs []pipe.Operator) error
+	// PauseOperators stops the cloud operators of a pipeline, but keeps their volumes.
+	PauseOperators(pipelineId string, inputs []pipe.Operator) error
+	// ResumeOperators starts the cloud operators of a paused pipeline again.
+	ResumeOperators(pipelineId string, inputs []pipe.Operator, pipelineConfig lib.PipelineConfig) error
+	// RestartOperators replaces the running cloud operators of a pipeline with new instances of the same configuration.
+	RestartOperators(pipelineId string, inputs []pipe.Operator) error
+	// RestartOperator restarts a single cloud operator, drivers running the operators of a pipeline in one pod return an input error.
+	RestartOperator(pipelineId string, input pipe.Operator) error
+	GetOperatorsStatus(pipelineId string, inputs []pipe.Operator) ([]lib.OperatorStatus, error)
 	GetPipelineStatus(pipelineId string) (lib.PipelineStatus, error)
 	GetPipelinesStatus() ([]lib.PipelineStatus, error)
+	GetPipelineResources() ([]lib.PipelineResource, error)
+	DeletePipelineResource(resource lib.PipelineResource) error
+}
+
+// VersionedDriver is implemented by drivers which can run several versions of a pipeline's cloud operators side by side,
+// it is required for blue/green updates. The unversioned deployment created by CreateOperators has the empty version.
+type VersionedDriver interface {
+	CreateOperatorsVersion(pipelineId, version string, input []pipe.Operator, pipelineConfig lib.PipelineConfig) error
+	DeleteOperatorsVersion(pipelineId, version string, inputs []pipe.Operator) error
+	GetPipelineVersionStatus(pipelineId, version string) (lib.PipelineStatus, error)
+	GetPipelineVersions(pipelineId string) ([]string, error)
+}
+
+// StatusWatcher is implemented by drivers which report status changes of pipelines as they happen.
+// onChange receives the combined status of the pipeline's cloud operators.
+type StatusWatcher interface {
+	WatchPipelinesStatus(ctx context.Context, onChange func(pipelineId string, status lib.PipelineStatus)) error
+}
+
+// StateStorage is implemented by drivers which can keep the state of pipelines, e.g. whether they are paused, in the cluster,
+// so that it is shared by all instances of the engine and deleted together with the pipeline's resources.
+type StateStorage interface {
+	StateStore(name string) store.Store[json.RawMessage]
+}
+
+// LogDriver is implemented by drivers which can read the container logs of cloud operators.
+type LogDriver interface {
+	GetOperatorLogs(ctx context.Context, pipelineId string, operator pipe.Operator, options lib.LogOptions) (io.ReadCloser, error)
 }
 
 type ParsingApiService interface {
@@ -48,6 +91,7 @@ type PermissionApiService interface {
 
 type Kafka2MqttApiService interface {
 	StartOperatorInstance(operatorName, operatorID string, pipelineID, userI, token string) (kafka2mqtt_api.Instance, error)
+	GetOperatorInstanceConfig(operatorName, operatorID string, pipelineID, userID string) kafka2mqtt_api.Instance
 	RemoveInstance(id, pipelineID, userID, token string) error
 }
 
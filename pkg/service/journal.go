@@ -0,0 +1,254 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+var errInterrupted = errors.New("operation interrupted by restart")
+
+// replayOperations finishes or rolls back operations which were interrupted by a restart.
+// A start or update is finished if all operators were started and only the registry update is missing,
+// otherwise everything it did so far is compensated. A deletion is only finished if its operators were stopped,
+// which is done after the ownership of the pipeline was checked, otherwise it is rolled back.
+func (f *FlowEngine) replayOperations() {
+	entries := f.operations.unfinished()
+	if len(entries) > 0 {
+		util.Logger.Warn("replaying interrupted operations", "count", len(entries))
+	}
+	for _, entry := range entries {
+		f.replayOperation(entry)
+	}
+}
+
+func (f *FlowEngine) replayOperation(entry journalEntry) {
+	operation := entry.Operation
+	util.Logger.Info("replaying operation", "operation", operation.Id, "type", operation.Type, "pipeline", operation.PipelineId, "state", operation.State)
+	if operation.PipelineId != "" {
+		f.locks.lock(operation.PipelineId)
+		defer f.locks.unlock(operation.PipelineId)
+	}
+
+	s := &saga{store: f.operations, operation: operation, pipeline: entry.Pipeline}
+	for i, step := range s.operation.Steps {
+		if step.State == lib.StepStateRunning {
+			s.operation.Steps[i].State = lib.StepStateInterrupted
+		}
+		s.compensations = append(s.compensations, f.replayCompensation(operation, entry.Pipeline, s.operation.Steps[i]))
+	}
+
+	if operation.Type == lib.OperationTypeDelete {
+		if !s.stepDone(stepStopOperators) {
+			_ = s.fail(errInterrupted)
+			return
+		}
+		f.finishDelete(s)
+		return
+	}
+	if operation.State != lib.OperationStateForwardingEnabled || !s.onlyRegistryUpdateMissing() {
+		_ = s.fail(errInterrupted)
+		return
+	}
+	if s.pipeline != nil {
+		pipeline := *s.pipeline
+		err := s.step(stepUpdateRegistry, nil, func() error {
+			return f.pipelineService.UpdatePipeline(&pipeline, operation.UserId, "")
+		}, nil)
+		if err != nil {
+			_ = s.fail(err)
+			return
+		}
+	}
+	util.Logger.Info("finished interrupted operation", "operation", operation.Id)
+	s.complete()
+}
+
+// finishDelete removes a pipeline whose operators were already stopped from the registry.
+func (f *FlowEngine) finishDelete(s *saga) {
+	pipelineId := s.operation.PipelineId
+	if _, err := f.getPipelineAdmin(pipelineId); err != nil {
+		var notFoundErr *lib.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			f.forgetPipelineState(pipelineId)
+			s.complete()
+			return
+		}
+		_ = s.fail(err)
+		return
+	}
+	err := s.step(stepDeleteFromRegistry, nil, func() error {
+		return f.pipelineService.DeletePipeline(pipelineId, s.operation.UserId, "")
+	}, nil)
+	if err != nil {
+		_ = s.fail(err)
+		return
+	}
+	f.forgetPipelineState(pipelineId)
+	util.Logger.Info("finished interrupted operation", "operation", s.operation.Id)
+	s.complete()
+}
+
+// stepDone reports whether the step name was completed.
+func (s *saga) stepDone(name string) bool {
+	for _, step := range s.operation.Steps {
+		if step.Name == name && step.State == lib.StepStateDone {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyRegistryUpdateMissing reports whether all steps but the idempotent registry update are done.
+func (s *saga) onlyRegistryUpdateMissing() bool {
+	for _, step := range s.operation.Steps {
+		if step.State != lib.StepStateDone && step.Name != stepUpdateRegistry {
+			return false
+		}
+	}
+	return true
+}
+
+// replayCompensation rebuilds the compensating action of a journaled step, pipeline is the one journaled with the operation if any.
+// As no user token is available after a restart, the services are called with the user ID only.
+func (f *FlowEngine) replayCompensation(operation lib.Operation, pipeline *pipe.Pipeline, step lib.OperationStep) func() error {
+	pipelineId := operation.PipelineId
+	switch step.Name {
+	case stepRegisterPipeline:
+		if pipelineId == "" {
+			return nil
+		}
+		return func() error {
+			return f.pipelineService.DeletePipeline(pipelineId, operation.UserId, "")
+		}
+	case stepCreateCloudOperators:
+		// the operators created by an update replace those of the old pipeline, which are restored by
+		// the compensation of stepStopOldOperators or the reconciler and must keep their volumes
+		if operation.Type != lib.OperationTypeStart {
+			return nil
+		}
+		var cloudOperators []pipe.Operator
+		if pipeline != nil {
+			_, cloudOperators = seperateOperators(*pipeline)
+		}
+		return func() error {
+			return f.driver.DeleteOperators(pipelineId, cloudOperators)
+		}
+	case stepCreateVersion:
+		driver, ok := f.driver.(VersionedDriver)
+		if !ok {
+			return nil
+		}
+		return func() error {
+			return driver.DeleteOperatorsVersion(pipelineId, step.Data["version"], nil)
+		}
+	case stepEnableCloudToFog:
+		if step.Data["instanceId"] == "" {
+			return nil
+		}
+		return func() error {
+			return f.kafak2mqttService.RemoveInstance(step.Data["instanceId"], pipelineId, operation.UserId, "")
+		}
+	case stepStartLocalOperator:
+		operator := pipe.Operator{Id: step.Data["operatorId"], OperatorId: step.Data["baseOperatorId"]}
+		return func() error {
+			return stopFogOperator(pipelineId, operator, step.Data["userId"])
+		}
+	case stepEnableFogToCloud:
+		operator := pipe.Operator{Id: step.Data["operatorId"], OutputTopic: step.Data["outputTopic"]}
+		operator.UpstreamConfig.Enabled = true
+		return func() error {
+			return f.disableFogToCloudForwarding(operator, pipelineId, step.Data["userId"], "")
+		}
+	case stepMarkPaused:
+		return func() error {
+			return f.paused.Delete(pipelineId)
+		}
+	case stepUnmarkPaused:
+		return func() error {
+			return f.paused.Put(pipelineId, lib.PausedPipeline{PipelineId: pipelineId, UserId: operation.UserId, PausedAt: operation.CreatedAt})
+		}
+	case stepStoreSchedule:
+		return func() error {
+			return f.restoreSchedule(pipelineId, operation.UserId, step.Data)
+		}
+	case stepStoreResources:
+		return func() error {
+			return f.restoreResources(pipelineId, step.Data)
+		}
+	case stepStoreDeploymentMode:
+		return func() error {
+			return f.restoreDeploymentMode(pipelineId, step.Data)
+		}
+	case stepPauseCloudOperators:
+		return func() error {
+			pipeline, err := f.getPipelineAdmin(pipelineId)
+			if err != nil {
+				return err
+			}
+			_, cloudOperators := seperateOperators(pipeline)
+			pipeConfig := f.createPipelineConfig(pipeline)
+			pipeConfig.UserId = operation.UserId
+			return f.driver.ResumeOperators(pipelineId, cloudOperators, pipeConfig)
+		}
+	case stepResumeCloudOperators:
+		return func() error {
+			pipeline, err := f.getPipelineAdmin(pipelineId)
+			if err != nil {
+				return err
+			}
+			_, cloudOperators := seperateOperators(pipeline)
+			return f.driver.PauseOperators(pipelineId, cloudOperators)
+		}
+	case stepStopForwarding:
+		return func() error {
+			pipeline, err := f.getPipelineAdmin(pipelineId)
+			if err != nil {
+				return err
+			}
+			return f.restoreChangedOperators(pipeline, operatorDiff{stop: pipeline}, operation.UserId, "")
+		}
+	case stepStopOldOperators:
+		// the registry still holds the old pipeline, as the update did not finish
+		return func() error {
+			oldPipeline, err := f.getPipelineAdmin(pipelineId)
+			if err != nil {
+				return err
+			}
+			return f.restoreChangedOperators(oldPipeline, diffFromStepData(oldPipeline, step.Data), operation.UserId, "")
+		}
+	}
+	return nil
+}
+
+func (f *FlowEngine) getPipelineAdmin(id string) (pipeline pipe.Pipeline, err error) {
+	pipelines, err := f.pipelineService.GetPipelinesAdmin()
+	if err != nil {
+		return
+	}
+	for _, p := range pipelines {
+		if p.Id == id {
+			return p, nil
+		}
+	}
+	err = lib.NewNotFoundError(errors.New("pipeline not found: " + id))
+	return
+}
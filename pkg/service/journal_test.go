@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_replayOperations(t *testing.T) {
+	util.InitStructLogger("error")
+	journal, err := store.NewFileStore[journalEntry](t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a start operation interrupted while creating the kafka2mqtt instances
+	before := newTestEngine(nil, nil)
+	before.operations = newOperationStore(journal)
+	s := before.newSaga(lib.OperationTypeStart, "", "user")
+	_ = s.step(stepRegisterPipeline, nil, func() error { return nil }, nil)
+	s.setPipelineId("pid")
+	_ = s.step(stepCreateCloudOperators, nil, func() error { return nil }, nil)
+	s.advance(lib.OperationStateDriverCreated)
+	_ = s.step(stepEnableCloudToFog, map[string]string{"operatorId": "op", "instanceId": "instance"}, func() error { return nil }, nil)
+	s.operation.Steps = append(s.operation.Steps, lib.OperationStep{Name: stepEnableCloudToFog, State: lib.StepStateRunning})
+	s.save()
+
+	operations := newOperationStore(journal)
+	if err = operations.load(); err != nil {
+		t.Fatal(err)
+	}
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", UserId: "user"}}}
+	kafka2mqtt := &fakeKafka2MqttService{}
+	driver := newFakeDriver()
+	f := newTestEngine(driver, pipelines)
+	f.kafak2mqttService = kafka2mqtt
+	f.operations = operations
+	f.replayOperations()
+
+	if len(kafka2mqtt.removed) != 1 || kafka2mqtt.removed[0] != "instance" {
+		t.Errorf("unexpected removed instances %v", kafka2mqtt.removed)
+	}
+	if len(driver.deleted) != 1 || driver.deleted[0] != "pid" {
+		t.Errorf("unexpected deleted operators %v", driver.deleted)
+	}
+	if len(pipelines.deleted) != 1 || pipelines.deleted[0] != "pid" {
+		t.Errorf("unexpected deleted pipelines %v", pipelines.deleted)
+	}
+	stored, err := journal.Get(s.operation.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Operation.State != lib.OperationStateCompensated {
+		t.Errorf("expected compensated operation, got %s", stored.Operation.State)
+	}
+	if len(f.operations.unfinished()) != 0 {
+		t.Error("expected no unfinished operations after replay")
+	}
+}
+
+func TestFlowEngine_replayUpdate(t *testing.T) {
+	// an update interrupted after creating its operators must not delete the operators of the pipeline
+	driver := newFakeDriver()
+	f := newTestEngine(driver, &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", UserId: "user"}}})
+	s := f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+	_ = s.step(stepCreateCloudOperators, nil, func() error { return nil }, nil)
+	s.operation.Steps = append(s.operation.Steps, lib.OperationStep{Name: stepEnableCloudToFog, State: lib.StepStateRunning})
+	s.save()
+
+	f.replayOperations()
+	if len(driver.deleted) != 0 {
+		t.Errorf("unexpected deleted operators %v", driver.deleted)
+	}
+	if len(f.operations.unfinished()) != 0 {
+		t.Error("expected no unfinished operations after replay")
+	}
+}
+
+func TestFlowEngine_replayDelete(t *testing.T) {
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", UserId: "user"}}}
+	f := newTestEngine(newFakeDriver(), pipelines)
+	f.queue = make(chan func(), 2)
+
+	if _, err := f.DeletePipelineAsync("pid", "other", ""); err == nil {
+		t.Error("expected deletion of a pipeline of another user to be rejected")
+	}
+	if len(f.operations.unfinished()) != 0 {
+		t.Error("expected rejected deletion not to be journaled")
+	}
+
+	// a queued deletion never ran, one was interrupted after its operators were stopped
+	queued, err := f.DeletePipelineAsync("pid", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := f.newSaga(lib.OperationTypeDelete, "pid", "user")
+	_ = s.step(stepStopOperators, nil, func() error { return nil }, nil)
+	f.replayOperations()
+
+	if operation, _ := f.GetOperation(queued.Id); operation.State != lib.OperationStateCompensated {
+		t.Errorf("expected queued deletion to be rolled back, got %s", operation.State)
+	}
+	if operation, _ := f.GetOperation(s.operation.Id); operation.State != lib.OperationStateCompleted {
+		t.Errorf("expected stopped deletion to be finished, got %s", operation.State)
+	}
+	if len(pipelines.deleted) != 1 || pipelines.deleted[0] != "pid" {
+		t.Errorf("expected pipeline to be deleted once, got %v", pipelines.deleted)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// GetOperatorsStatus returns the container state of each cloud operator of a pipeline.
+func (f *FlowEngine) GetOperatorsStatus(id, userId, token string) ([]lib.OperatorStatus, error) {
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return nil, err
+	}
+	_, cloudOperators := seperateOperators(pipeline)
+	if len(cloudOperators) == 0 {
+		return []lib.OperatorStatus{}, nil
+	}
+	return f.driver.GetOperatorsStatus(id, cloudOperators)
+}
+
+// RestartOperator restarts a single operator of a pipeline, a local operator is stopped and started again.
+func (f *FlowEngine) RestartOperator(id, operatorId, userId, token string) error {
+	s := f.newSaga(lib.OperationTypeRestart, id, userId)
+	util.Logger.Debug("engine - restart operator: "+operatorId, "pipeline", id)
+	f.locks.lock(id)
+	defer f.locks.unlock(id)
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	idx := slices.IndexFunc(pipeline.Operators, func(operator pipe.Operator) bool { return operator.Id == operatorId })
+	if idx == -1 {
+		return s.fail(lib.NewNotFoundError(errors.New("operator not found: " + operatorId)))
+	}
+	if f.isPaused(id) {
+		return s.fail(lib.NewInputError(errors.New("cannot restart operator of paused pipeline")))
+	}
+	operator := pipeline.Operators[idx]
+	if operator.DeploymentType == "local" {
+		pipeConfig := f.createPipelineConfig(pipeline)
+		pipeConfig.UserId = userId
+		err = restartLocalOperator(s, pipeline, operator, pipeConfig)
+	} else {
+		err = s.step(stepRestartCloudOperator, map[string]string{"operatorId": operator.Id}, func() error {
+			return f.driver.RestartOperator(id, operator)
+		}, nil)
+	}
+	if err != nil {
+		return s.fail(err)
+	}
+	s.complete()
+	return nil
+}
+
+// GetOperatorLogs streams the container logs of a cloud operator of a pipeline.
+func (f *FlowEngine) GetOperatorLogs(ctx context.Context, id, operatorId string, options lib.LogOptions, userId, token string) (io.ReadCloser, error) {
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(pipeline.Operators, func(operator pipe.Operator) bool { return operator.Id == operatorId })
+	if idx == -1 {
+		return nil, lib.NewNotFoundError(errors.New("operator not found: " + operatorId))
+	}
+	operator := pipeline.Operators[idx]
+	if operator.DeploymentType == "local" {
+		return nil, lib.NewInputError(errors.New("logs of local operators are not available"))
+	}
+	driver, ok := f.driver.(LogDriver)
+	if !ok {
+		return nil, lib.NewUnavailableError(errors.New("driver does not provide operator logs"))
+	}
+	return driver.GetOperatorLogs(ctx, id, operator, options)
+}
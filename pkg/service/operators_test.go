@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_GetOperatorLogs(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", Operators: []pipe.Operator{
+		{Id: "cloud", DeploymentType: "cloud"},
+		{Id: "local", DeploymentType: "local"},
+	}}}}
+	driver := &fakeLogDriver{fakeDriver: newFakeDriver()}
+	f := newTestEngine(driver, pipelines)
+	tail := int64(10)
+
+	logs, err := f.GetOperatorLogs(context.Background(), "pid", "cloud", lib.LogOptions{TailLines: &tail, Follow: true}, "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(logs)
+	if string(content) != "logs of cloud" || driver.logOptions.TailLines != &tail || !driver.logOptions.Follow {
+		t.Errorf("unexpected logs %q with options %+v", content, driver.logOptions)
+	}
+
+	if _, err = f.GetOperatorLogs(context.Background(), "pid", "unknown", lib.LogOptions{}, "user", ""); !errors.As(err, new(*lib.NotFoundError)) {
+		t.Errorf("expected not found error for unknown operator, got %v", err)
+	}
+	if _, err = f.GetOperatorLogs(context.Background(), "pid", "local", lib.LogOptions{}, "user", ""); !errors.As(err, new(*lib.InputError)) {
+		t.Errorf("expected input error for local operator, got %v", err)
+	}
+	f.driver = newFakeDriver()
+	if _, err = f.GetOperatorLogs(context.Background(), "pid", "cloud", lib.LogOptions{}, "user", ""); !errors.As(err, new(*lib.UnavailableError)) {
+		t.Errorf("expected unavailable error for driver without logs, got %v", err)
+	}
+}
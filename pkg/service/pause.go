@@ -0,0 +1,191 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// PausePipeline stops the cloud and local operators and the forwarding of a pipeline,
+// but keeps its registry entry, volumes and consumer groups. Pausing a paused pipeline does nothing.
+func (f *FlowEngine) PausePipeline(id, userId, token string) error {
+	s := f.newSaga(lib.OperationTypePause, id, userId)
+	util.Logger.Debug("engine - pause pipeline: " + id)
+	f.locks.lock(id)
+	defer f.locks.unlock(id)
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	return f.pausePipeline(s, pipeline, userId, token)
+}
+
+func (f *FlowEngine) pausePipeline(s *saga, pipeline pipe.Pipeline, userId, token string) (err error) {
+	id := pipeline.Id
+	if f.isPaused(id) {
+		s.complete()
+		return nil
+	}
+
+	// mark the pipeline first, so that the reconciler does not recreate it while pausing
+	paused := lib.PausedPipeline{PipelineId: id, UserId: userId, PausedAt: time.Now().UTC()}
+	err = s.step(stepMarkPaused, nil, func() error {
+		return f.paused.Put(id, paused)
+	}, func() error {
+		return f.paused.Delete(id)
+	})
+	if err != nil {
+		return s.fail(err)
+	}
+	_, cloudOperators := seperateOperators(pipeline)
+	if len(cloudOperators) > 0 {
+		pipeConfig := f.createPipelineConfig(pipeline)
+		pipeConfig.UserId = userId
+		err = s.step(stepPauseCloudOperators, nil, func() error {
+			return f.driver.PauseOperators(id, cloudOperators)
+		}, func() error {
+			return f.driver.ResumeOperators(id, cloudOperators, pipeConfig)
+		})
+		if err != nil {
+			return s.fail(err)
+		}
+	}
+	err = s.step(stepStopForwarding, nil, func() error {
+		return f.stopForwarding(pipeline, token)
+	}, func() error {
+		return f.restoreChangedOperators(pipeline, operatorDiff{stop: pipeline}, userId, token)
+	})
+	if err != nil {
+		return s.fail(err)
+	}
+
+	// the kafka2mqtt instances are removed, resuming creates new ones
+	pipeline.Operators = withoutForwardingInstances(pipeline.Operators)
+	s.setPipeline(pipeline)
+	err = s.step(stepUpdateRegistry, nil, func() error {
+		return f.pipelineService.UpdatePipeline(&pipeline, userId, token)
+	}, nil)
+	if err != nil {
+		return s.fail(err)
+	}
+	s.complete()
+	util.Logger.Debug("paused pipeline: " + id)
+	return nil
+}
+
+// ResumePipeline starts the operators and the forwarding of a paused pipeline again.
+// Resuming a pipeline which is not paused does nothing.
+func (f *FlowEngine) ResumePipeline(id, userId, token string) error {
+	s := f.newSaga(lib.OperationTypeResume, id, userId)
+	util.Logger.Debug("engine - resume pipeline: " + id)
+	f.locks.lock(id)
+	defer f.locks.unlock(id)
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	return f.resumePipeline(s, pipeline, userId, token)
+}
+
+func (f *FlowEngine) resumePipeline(s *saga, pipeline pipe.Pipeline, userId, token string) error {
+	id := pipeline.Id
+	paused, err := f.paused.Get(id)
+	if errors.Is(err, store.ErrNotFound) {
+		s.complete()
+		return nil
+	}
+	if err != nil {
+		return s.fail(err)
+	}
+
+	pipeConfig := f.createPipelineConfig(pipeline)
+	pipeConfig.UserId = userId
+	_, cloudOperators := seperateOperators(pipeline)
+	if len(cloudOperators) > 0 {
+		err = s.step(stepResumeCloudOperators, nil, func() error {
+			return f.driver.ResumeOperators(id, cloudOperators, pipeConfig)
+		}, func() error {
+			return f.driver.PauseOperators(id, cloudOperators)
+		})
+		if err != nil {
+			return s.fail(err)
+		}
+		s.advance(lib.OperationStateDriverCreated)
+	}
+	newOperators, err := f.startForwarding(s, pipeline, pipeConfig, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	err = s.step(stepUnmarkPaused, nil, func() error {
+		return f.paused.Delete(id)
+	}, func() error {
+		return f.paused.Put(id, paused)
+	})
+	if err != nil {
+		return s.fail(err)
+	}
+
+	pipeline.Operators = mergeOperators(pipeline.Operators, newOperators)
+	s.setPipeline(pipeline)
+	err = s.step(stepUpdateRegistry, nil, func() error {
+		return f.pipelineService.UpdatePipeline(&pipeline, userId, token)
+	}, nil)
+	if err != nil {
+		return s.fail(err)
+	}
+	s.complete()
+	util.Logger.Debug("resumed pipeline: " + id)
+	return nil
+}
+
+func (f *FlowEngine) isPaused(id string) bool {
+	_, err := f.paused.Get(id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		util.Logger.Error("cannot get paused state", "pipeline", id, "error", err)
+	}
+	return err == nil
+}
+
+// forgetPipelineState removes the paused state and the schedule of a deleted pipeline.
+func (f *FlowEngine) forgetPipelineState(id string) {
+	if err := f.paused.Delete(id); err != nil {
+		util.Logger.Error("cannot remove paused state", "pipeline", id, "error", err)
+	}
+	if err := f.schedules.Delete(id); err != nil {
+		util.Logger.Error("cannot remove schedule", "pipeline", id, "error", err)
+	}
+	if err := f.resources.Delete(id); err != nil {
+		util.Logger.Error("cannot remove resources", "pipeline", id, "error", err)
+	}
+	if err := f.deploymentModes.Delete(id); err != nil {
+		util.Logger.Error("cannot remove deployment mode", "pipeline", id, "error", err)
+	}
+}
+
+func withoutForwardingInstances(operators []pipe.Operator) (newOperators []pipe.Operator) {
+	for _, operator := range operators {
+		operator.DownstreamConfig.InstanceID = ""
+		newOperators = append(newOperators, operator)
+	}
+	return
+}
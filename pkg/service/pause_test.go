@@ -0,0 +1,76 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_PausePipeline(t *testing.T) {
+	util.InitStructLogger("error")
+	operator := pipe.Operator{Id: "op", DeploymentType: "cloud"}
+	operator.DownstreamConfig.Enabled = true
+	operator.DownstreamConfig.InstanceID = "instance"
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", Operators: []pipe.Operator{operator}}}}
+	kafka2mqtt := &fakeKafka2MqttService{}
+	driver := newFakeDriver()
+	driver.status["pid"] = lib.PipelineStatus{Running: true}
+	f := newTestEngine(driver, pipelines)
+	f.kafak2mqttService = kafka2mqtt
+
+	if err := f.PausePipeline("pid", "user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if driver.status["pid"].Running || !f.isPaused("pid") {
+		t.Error("expected paused pipeline")
+	}
+	if len(kafka2mqtt.removed) != 1 || kafka2mqtt.removed[0] != "instance" {
+		t.Errorf("unexpected removed instances %v", kafka2mqtt.removed)
+	}
+	if id := pipelines.pipelines[0].Operators[0].DownstreamConfig.InstanceID; id != "" {
+		t.Errorf("expected instance to be removed from registry, got %s", id)
+	}
+	if err := f.PausePipeline("pid", "user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(kafka2mqtt.removed) != 1 {
+		t.Error("expected pausing a paused pipeline to do nothing")
+	}
+	request := lib.PipelineRequest{Id: "pid"}
+	if _, err := f.UpdatePipeline(request, "user", ""); !errors.As(err, new(*lib.InputError)) {
+		t.Errorf("expected input error for update of paused pipeline, got %v", err)
+	}
+	_, errs := f.ExecuteBatch([]lib.BatchAction{{Action: lib.BatchActionUpdate, Request: &request}}, "user", "")
+	if !errors.As(errs[0], new(*lib.InputError)) {
+		t.Errorf("expected input error for batch update of paused pipeline, got %v", errs[0])
+	}
+
+	if err := f.ResumePipeline("pid", "user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.status["pid"].Running || f.isPaused("pid") {
+		t.Error("expected resumed pipeline")
+	}
+	if id := pipelines.pipelines[0].Operators[0].DownstreamConfig.InstanceID; id != "instance-op" {
+		t.Errorf("expected new instance in registry, got %s", id)
+	}
+}
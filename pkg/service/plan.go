@@ -0,0 +1,157 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"github.com/google/uuid"
+)
+
+// PlanStartPipeline runs the same setup and permission checks as StartPipeline and returns what would be deployed,
+// without registering the pipeline, creating resources or publishing messages.
+// The pipeline ID is only known after registration, a nil UUID is used in its place.
+func (f *FlowEngine) PlanStartPipeline(pipelineRequest lib.PipelineRequest, userId string, token string) (plan lib.PipelinePlan, err error) {
+	util.Logger.Debug("engine - plan start pipeline: " + pipelineRequest.Id)
+	pipeline, err := f.setupPipeline(pipelineRequest, userId, token)
+	if err != nil {
+		return
+	}
+	resources, err := f.resolveResources(pipelineRequest, *pipeline)
+	if err != nil {
+		return
+	}
+	deploymentMode, err := f.resolveDeploymentMode(pipelineRequest)
+	if err != nil {
+		return
+	}
+	pipeline.Id = uuid.Nil.String()
+	pipeConfig := f.createPipelineConfig(*pipeline)
+	pipeConfig.UserId = userId
+	pipeConfig.Resources = resources
+	pipeConfig.DeploymentMode = deploymentMode
+	return f.planOperators(*pipeline, pipeConfig)
+}
+
+// PlanUpdatePipeline runs the same setup and permission checks as UpdatePipeline and returns what would be
+// removed and deployed, without touching the pipeline registry, the driver or publishing messages.
+// As UpdatePipeline, only operators which changed are part of the plan.
+func (f *FlowEngine) PlanUpdatePipeline(pipelineRequest lib.PipelineRequest, userId string, token string) (plan lib.PipelinePlan, err error) {
+	util.Logger.Debug("engine - plan update pipeline: " + pipelineRequest.Id)
+	oldPipeline, err := f.pipelineService.GetPipeline(pipelineRequest.Id, userId, token)
+	if err != nil {
+		return
+	}
+	pipeline, err := f.setupPipeline(pipelineRequest, userId, token)
+	if err != nil {
+		return
+	}
+	reuseApplicationIds(pipeline, oldPipeline)
+	resources, err := f.resolveResources(pipelineRequest, *pipeline)
+	if err != nil {
+		return
+	}
+	deploymentMode, err := f.resolveDeploymentMode(pipelineRequest)
+	if err != nil {
+		return
+	}
+	pipeline.Id = oldPipeline.Id
+	pipeline.Operators = addPipelineIDToFogTopic(pipeline.Operators, pipeline.Id)
+	pipeConfig := f.createPipelineConfig(*pipeline)
+	pipeConfig.UserId = userId
+	pipeConfig.Resources = resources
+	pipeConfig.DeploymentMode = deploymentMode
+	diff := diffOperators(oldPipeline, *pipeline)
+	if redeployRequired(*pipeline, f.createPipelineConfig(oldPipeline), pipeConfig) {
+		diff.redeployCloud = true
+	}
+
+	plan, err = f.planChanges(*pipeline, diff, pipeConfig)
+	if err != nil {
+		return
+	}
+
+	_, cloudOperators := seperateOperators(oldPipeline)
+	if diff.redeployCloud && len(cloudOperators) > 0 {
+		var removed []lib.PlannedResource
+		removed, err = f.driver.PlanOperators(oldPipeline.Id, cloudOperators, f.createPipelineConfig(oldPipeline))
+		if err != nil {
+			return
+		}
+		for _, resource := range removed {
+			plan.Removed = append(plan.Removed, lib.PlannedResource{Kind: resource.Kind, Name: resource.Name})
+		}
+	}
+	localOperators, cloudOperators := seperateOperators(diff.stop)
+	for _, operator := range cloudOperators {
+		if operator.DownstreamConfig.Enabled && operator.DownstreamConfig.InstanceID != "" {
+			plan.Removed = append(plan.Removed, lib.PlannedResource{Kind: lib.ResourceKindKafka2MqttInstance, Name: operator.DownstreamConfig.InstanceID})
+		}
+	}
+	var stopMessages []lib.PlannedMessage
+	for _, operator := range localOperators {
+		stopMessages = append(stopMessages, fogOperatorStopMessage(oldPipeline.Id, operator, oldPipeline.UserId))
+		if operator.UpstreamConfig.Enabled {
+			stopMessages = append(stopMessages, upstreamDisableMessage(operator, oldPipeline.UserId))
+		}
+	}
+	plan.Messages = append(stopMessages, plan.Messages...)
+	return
+}
+
+// planOperators mirrors startOperators for a fully set up pipeline.
+func (f *FlowEngine) planOperators(pipeline pipe.Pipeline, pipeConfig lib.PipelineConfig) (plan lib.PipelinePlan, err error) {
+	pipeline.Operators = addPipelineIDToFogTopic(pipeline.Operators, pipeline.Id)
+	return f.planChanges(pipeline, diffOperators(pipe.Pipeline{}, pipeline), pipeConfig)
+}
+
+// planChanges lists what would be deployed for the operators the diff starts with pipeConfig.
+func (f *FlowEngine) planChanges(pipeline pipe.Pipeline, diff operatorDiff, pipeConfig lib.PipelineConfig) (plan lib.PipelinePlan, err error) {
+	localOperators, cloudOperators := seperateOperators(pipeline)
+
+	plan.Pipeline = pipeline
+	plan.CloudOperators = cloudOperators
+	plan.LocalOperators = localOperators
+	plan.Resources = []lib.PlannedResource{}
+	plan.Messages = []lib.PlannedMessage{}
+
+	if diff.redeployCloud && len(cloudOperators) > 0 {
+		var resources []lib.PlannedResource
+		resources, err = f.driver.PlanOperators(pipeline.Id, cloudOperators, pipeConfig)
+		if err != nil {
+			return
+		}
+		plan.Resources = append(plan.Resources, resources...)
+	}
+	startLocal, startCloud := seperateOperators(diff.start)
+	for _, operator := range startCloud {
+		if !operator.DownstreamConfig.Enabled {
+			continue
+		}
+		instance := f.kafak2mqttService.GetOperatorInstanceConfig(operator.Name, operator.Id, pipeline.Id, pipeline.UserId)
+		instance.CustomMqttPassword = nil
+		plan.Resources = append(plan.Resources, lib.PlannedResource{Kind: lib.ResourceKindKafka2MqttInstance, Name: operator.Id, Manifest: instance})
+	}
+	for _, operator := range startLocal {
+		plan.Messages = append(plan.Messages, fogOperatorStartMessage(operator, pipeConfig.PipelineId, pipeline.UserId))
+		if operator.UpstreamConfig.Enabled {
+			plan.Messages = append(plan.Messages, upstreamEnableMessage(operator, pipeline.UserId))
+		}
+	}
+	return
+}
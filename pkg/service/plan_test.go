@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	kafka2mqtt_api "github.com/SENERGY-Platform/analytics-flow-engine/pkg/kafka2mqtt-api"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_planOperators(t *testing.T) {
+	driver := newFakeDriver()
+	f := newTestEngine(driver, nil)
+	f.kafak2mqttService = &fakeKafka2MqttService{}
+	pipeline := pipe.Pipeline{
+		Id: "pid",
+		Operators: []pipe.Operator{
+			{Id: "cloud-1", DeploymentType: "cloud", DownstreamConfig: pipe.DownstreamConfig{Enabled: true}},
+			{Id: "local-1", DeploymentType: "local", OutputTopic: "fog-", UpstreamConfig: pipe.UpstreamConfig{Enabled: true}},
+		},
+	}
+
+	plan, err := f.planOperators(pipeline, lib.PipelineConfig{PipelineId: "pid", UserId: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.CloudOperators) != 1 || len(plan.LocalOperators) != 1 {
+		t.Fatalf("unexpected operator split: %d cloud, %d local", len(plan.CloudOperators), len(plan.LocalOperators))
+	}
+	if len(driver.planned) != 1 || driver.planned[0] != "cloud-1" {
+		t.Errorf("driver planned %v, expected only cloud-1", driver.planned)
+	}
+	if len(driver.created) != 0 || len(driver.deleted) != 0 {
+		t.Errorf("expected plan not to change operators, created %v, deleted %v", driver.created, driver.deleted)
+	}
+	if plan.LocalOperators[0].OutputTopic != "fog-pid" {
+		t.Errorf("pipeline ID not added to fog topic: %s", plan.LocalOperators[0].OutputTopic)
+	}
+	if len(plan.Resources) != 2 || plan.Resources[1].Kind != lib.ResourceKindKafka2MqttInstance {
+		t.Fatalf("unexpected resources: %+v", plan.Resources)
+	}
+	if instance := plan.Resources[1].Manifest.(kafka2mqtt_api.Instance); instance.CustomMqttPassword != nil {
+		t.Error("mqtt password must not be part of the plan")
+	}
+	if len(plan.Messages) != 2 {
+		t.Errorf("expected start and upstream enable message, got %+v", plan.Messages)
+	}
+}
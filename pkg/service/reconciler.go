@@ -0,0 +1,260 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+const deploymentPrefix = "pipeline-"
+
+type reconcileState struct {
+	mu         sync.Mutex
+	running    sync.Mutex
+	lastResult *lib.ReconcileResult
+}
+
+// pipelineLocks serializes the lifecycle operations of each pipeline. Background jobs like the reconciler
+// only try to lock a pipeline and skip it while an operation is in progress.
+type pipelineLocks struct {
+	mu    sync.Mutex
+	locks map[string]*pipelineLock
+	// registering is held shared while a new pipeline is registered and locked and exclusively while the
+	// registry is listed, so that every listed pipeline is either locked by its start or completely started.
+	registering sync.RWMutex
+}
+
+type pipelineLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newPipelineLocks() *pipelineLocks {
+	return &pipelineLocks{locks: make(map[string]*pipelineLock)}
+}
+
+func (l *pipelineLocks) get(id string) *pipelineLock {
+	lock, ok := l.locks[id]
+	if !ok {
+		lock = &pipelineLock{}
+		l.locks[id] = lock
+	}
+	return lock
+}
+
+func (l *pipelineLocks) lock(id string) {
+	l.mu.Lock()
+	lock := l.get(id)
+	lock.refs++
+	l.mu.Unlock()
+	lock.Lock()
+}
+
+// tryLock locks the pipeline if no operation is in progress and reports whether it did.
+func (l *pipelineLocks) tryLock(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock := l.get(id)
+	if !lock.TryLock() {
+		return false
+	}
+	lock.refs++
+	return true
+}
+
+func (l *pipelineLocks) unlock(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock := l.locks[id]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, id)
+	}
+	lock.Unlock()
+}
+
+// register registers a new pipeline and locks it before it can be listed by listRegistered.
+// The caller has to unlock the returned ID.
+func (l *pipelineLocks) register(register func() (string, error)) (string, error) {
+	l.registering.RLock()
+	defer l.registering.RUnlock()
+	id, err := register()
+	if err != nil {
+		return "", err
+	}
+	l.lock(id)
+	return id, nil
+}
+
+// listRegistered lists the registered pipelines while no pipeline is being registered.
+func (l *pipelineLocks) listRegistered(list func() ([]pipe.Pipeline, error)) ([]pipe.Pipeline, error) {
+	l.registering.Lock()
+	defer l.registering.Unlock()
+	return list()
+}
+
+// runReconciler replays interrupted operations, reconciles the pipeline registry with the driver
+// once at startup and afterwards in the configured interval until ctx is cancelled.
+func (f *FlowEngine) runReconciler(ctx context.Context) {
+	f.replayOperations()
+	f.Reconcile()
+	if f.reconcileCfg.Interval <= 0 {
+		util.Logger.Info("periodic pipeline reconciliation disabled")
+		return
+	}
+	ticker := time.NewTicker(f.reconcileCfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			util.Logger.Info("stopping pipeline reconciler")
+			return
+		case <-ticker.C:
+			f.Reconcile()
+		}
+	}
+}
+
+// Reconcile compares the registered pipelines with the deployments known to the driver,
+// recreates missing pipelines which are not paused and, if enabled, removes deployments without a registry entry.
+func (f *FlowEngine) Reconcile() (result lib.ReconcileResult) {
+	f.reconcile.running.Lock()
+	defer f.reconcile.running.Unlock()
+
+	result.StartedAt = time.Now().UTC()
+	defer func() {
+		result.FinishedAt = time.Now().UTC()
+		f.reconcile.mu.Lock()
+		f.reconcile.lastResult = &result
+		f.reconcile.mu.Unlock()
+	}()
+
+	util.Logger.Info("syncing pipelines")
+	pipelines, err := f.locks.listRegistered(f.pipelineService.GetPipelinesAdmin)
+	if err != nil {
+		util.Logger.Error("cannot get pipelines for reconciliation", "error", err)
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+	statusTemp, err := f.driver.GetPipelinesStatus()
+	if err != nil {
+		util.Logger.Error("cannot get pipeline status for reconciliation", "error", err)
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+
+	missing, extra := CompareSlicesWithKey(
+		pipelines,
+		statusTemp,
+		func(a pipe.Pipeline) string { return a.Id },
+		func(b lib.PipelineStatus) string { return strings.TrimPrefix(b.Name, deploymentPrefix) },
+	)
+	if len(missing) > 0 {
+		util.Logger.Warn("found missing pipelines")
+		for _, item := range missing {
+			if f.isPaused(item.Id) {
+				util.Logger.Debug("skipping paused pipeline", "pipeline", item.Id)
+				result.Paused = append(result.Paused, item.Id)
+				continue
+			}
+			result.Missing = append(result.Missing, item.Id)
+			if !f.locks.tryLock(item.Id) {
+				util.Logger.Debug("skipping pipeline with operation in progress", "pipeline", item.Id)
+				result.Skipped = append(result.Skipped, item.Id)
+				continue
+			}
+			err = f.recreatePipeline(item)
+			f.locks.unlock(item.Id)
+			if err != nil {
+				util.Logger.Error("failed to recreate pipeline", "error", err, "pipeline", item.Id)
+				result.Errors = append(result.Errors, item.Id+": "+err.Error())
+				continue
+			}
+			result.Recreated = append(result.Recreated, item.Id)
+		}
+	}
+
+	if len(extra) > 0 {
+		for _, item := range extra {
+			if !strings.HasPrefix(item.Name, deploymentPrefix) {
+				continue
+			}
+			id := strings.TrimPrefix(item.Name, deploymentPrefix)
+			util.Logger.Warn("extra deployment", "deployment", item)
+			result.Extra = append(result.Extra, id)
+			if !f.reconcileCfg.RemoveExtra {
+				continue
+			}
+			if !f.locks.tryLock(id) {
+				util.Logger.Debug("skipping deployment with operation in progress", "pipeline", id)
+				result.Skipped = append(result.Skipped, id)
+				continue
+			}
+			util.Logger.Warn("removing extra deployment", "deployment", item.Name)
+			err = f.driver.DeleteOperators(id, nil)
+			f.locks.unlock(id)
+			if err != nil {
+				util.Logger.Error("failed to remove extra deployment", "error", err, "deployment", item.Name)
+				result.Errors = append(result.Errors, id+": "+err.Error())
+				continue
+			}
+			result.Removed = append(result.Removed, id)
+		}
+	}
+	return
+}
+
+// recreatePipeline starts the operators of a registered pipeline again, the caller has to hold its lock.
+func (f *FlowEngine) recreatePipeline(item pipe.Pipeline) error {
+	item.Image = ""
+	util.Logger.Warn("trying to recreate pipeline", "pipeline", item)
+	//first delete every resource that might still be present
+	err := f.stopOperators(item, "")
+	if err != nil {
+		util.Logger.Error("cannot stop operators", "error", err)
+		return err
+	}
+
+	s := f.newSaga(lib.OperationTypeRecreate, item.Id, item.UserId)
+	pipeConfig := f.createPipelineConfig(item)
+	pipeConfig.UserId = item.UserId
+	_, err = f.startOperators(s, item, pipeConfig, "")
+	if err != nil {
+		return s.fail(err)
+	}
+	s.complete()
+	return nil
+}
+
+// GetLastReconcileResult returns the result of the most recent reconciliation run.
+func (f *FlowEngine) GetLastReconcileResult() (result lib.ReconcileResult, err error) {
+	f.reconcile.mu.Lock()
+	defer f.reconcile.mu.Unlock()
+	if f.reconcile.lastResult == nil {
+		err = lib.NewNotFoundError(errors.New("no reconciliation has run yet"))
+		return
+	}
+	return *f.reconcile.lastResult, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+)
+
+func TestPipelineLocks(t *testing.T) {
+	locks := newPipelineLocks()
+	locks.lock("pid")
+	if locks.tryLock("pid") {
+		t.Fatal("expected locked pipeline not to be locked again")
+	}
+	if !locks.tryLock("other") {
+		t.Fatal("expected other pipeline to be locked")
+	}
+	locks.unlock("other")
+	locks.unlock("pid")
+	if !locks.tryLock("pid") {
+		t.Fatal("expected unlocked pipeline to be locked")
+	}
+	locks.unlock("pid")
+	if len(locks.locks) != 0 {
+		t.Errorf("expected no remaining locks, got %d", len(locks.locks))
+	}
+
+	id, err := locks.register(func() (string, error) { return "new", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locks.tryLock(id) {
+		t.Error("expected registered pipeline to be locked")
+	}
+	locks.unlock(id)
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ValidateResourcesConfig returns an error if the default profile is unknown or the maxima are no valid quantities.
+func ValidateResourcesConfig(cfg config.ResourcesConfig) error {
+	if _, ok := lib.ResourceProfiles[cfg.DefaultProfile]; !ok {
+		return fmt.Errorf("unknown default resource profile %q", cfg.DefaultProfile)
+	}
+	if cfg.MaxReplicas < 1 {
+		return errors.New("max replicas have to be at least 1")
+	}
+	if _, err := resource.ParseQuantity(cfg.MaxCpu); err != nil {
+		return fmt.Errorf("invalid max cpu: %w", err)
+	}
+	if _, err := resource.ParseQuantity(cfg.MaxMemory); err != nil {
+		return fmt.Errorf("invalid max memory: %w", err)
+	}
+	return nil
+}
+
+// resolveResources returns the resources of every cloud operator of pipeline. The resources of a node take
+// precedence over those of the request, then the profile is derived from the cost of the operator in the catalog
+// and finally the default profile is used. Operators persisting data are never scaled, as their volume can only be mounted once.
+func (f *FlowEngine) resolveResources(request lib.PipelineRequest, pipeline pipe.Pipeline) (map[string]lib.OperatorResources, error) {
+	nodes := make(map[string]*lib.OperatorResources)
+	for _, node := range request.Nodes {
+		nodes[node.NodeId] = node.Resources
+	}
+	_, cloudOperators := seperateOperators(pipeline)
+	resolved := make(map[string]lib.OperatorResources, len(cloudOperators))
+	for _, operator := range cloudOperators {
+		requested := nodes[operator.Id]
+		if requested == nil {
+			requested = request.Resources
+		}
+		var resources lib.OperatorResources
+		if requested != nil {
+			resources = *requested
+		}
+		if resources.Profile == "" {
+			resources.Profile = costProfile(operator.Cost, f.resourcesCfg.DefaultProfile)
+		}
+		if operator.PersistData && resources.Scaling != (lib.OperatorScaling{}) {
+			util.Logger.Info("operator persists data, ignoring its scaling", "operator", operator.Id)
+			resources.Scaling = lib.OperatorScaling{}
+		}
+		resources, err := f.completeResources(resources)
+		if err != nil {
+			return nil, lib.NewInputError(fmt.Errorf("invalid resources of operator %s: %w", operator.Id, err))
+		}
+		resolved[operator.Id] = resources
+	}
+	return resolved, nil
+}
+
+// costProfile maps the cost of an operator in the catalog to a profile, operators without cost get defaultProfile.
+func costProfile(cost uint, defaultProfile string) string {
+	switch {
+	case cost == 0:
+		return defaultProfile
+	case cost == 1:
+		return lib.ResourceProfileSmall
+	case cost == 2:
+		return lib.ResourceProfileMedium
+	default:
+		return lib.ResourceProfileLarge
+	}
+}
+
+// completeResources fills the values which are not given from the profile and checks that the requests
+// do not exceed the limits and the limits do not exceed the configured maxima.
+func (f *FlowEngine) completeResources(resources lib.OperatorResources) (lib.OperatorResources, error) {
+	profile, ok := lib.ResourceProfiles[resources.Profile]
+	if !ok {
+		return resources, fmt.Errorf("unknown profile %q", resources.Profile)
+	}
+	if resources.CpuRequest == "" {
+		resources.CpuRequest = profile.CpuRequest
+	}
+	if resources.MemoryRequest == "" {
+		resources.MemoryRequest = profile.MemoryRequest
+	}
+	if resources.CpuLimit == "" {
+		resources.CpuLimit = profile.CpuLimit
+	}
+	if resources.MemoryLimit == "" {
+		resources.MemoryLimit = profile.MemoryLimit
+	}
+	if err := checkQuantities("cpu", resources.CpuRequest, resources.CpuLimit, f.resourcesCfg.MaxCpu); err != nil {
+		return resources, err
+	}
+	if err := checkQuantities("memory", resources.MemoryRequest, resources.MemoryLimit, f.resourcesCfg.MaxMemory); err != nil {
+		return resources, err
+	}
+	scaling, err := f.completeScaling(resources.Scaling)
+	resources.Scaling = scaling
+	return resources, err
+}
+
+// completeScaling defaults the minimum replicas of an autoscaled operator to its replicas and checks
+// that the replicas do not exceed the configured maximum and autoscaling has a target.
+func (f *FlowEngine) completeScaling(scaling lib.OperatorScaling) (lib.OperatorScaling, error) {
+	if scaling.Replicas < 0 || scaling.MinReplicas < 0 || scaling.MaxReplicas < 0 || scaling.TargetCpuUtilization < 0 || scaling.TargetConsumerLag < 0 {
+		return scaling, errors.New("scaling must not be negative")
+	}
+	maxReplicas := int32(f.resourcesCfg.MaxReplicas)
+	if !scaling.Autoscaled() {
+		if scaling.MinReplicas > 0 || scaling.TargetCpuUtilization > 0 || scaling.TargetConsumerLag > 0 {
+			return scaling, errors.New("autoscaling requires max replicas")
+		}
+		if scaling.Replicas > maxReplicas {
+			return scaling, fmt.Errorf("replicas %d exceed maximum %d", scaling.Replicas, maxReplicas)
+		}
+		return scaling, nil
+	}
+	if scaling.MinReplicas == 0 {
+		scaling.MinReplicas = max(scaling.Replicas, 1)
+	}
+	if scaling.MinReplicas > scaling.MaxReplicas {
+		return scaling, fmt.Errorf("min replicas %d exceed max replicas %d", scaling.MinReplicas, scaling.MaxReplicas)
+	}
+	if scaling.MaxReplicas > maxReplicas {
+		return scaling, fmt.Errorf("max replicas %d exceed maximum %d", scaling.MaxReplicas, maxReplicas)
+	}
+	if scaling.TargetCpuUtilization == 0 && scaling.TargetConsumerLag == 0 {
+		return scaling, errors.New("autoscaling requires a target cpu utilization or consumer lag")
+	}
+	return scaling, nil
+}
+
+func checkQuantities(name, request, limit, maximum string) error {
+	requestQuantity, err := resource.ParseQuantity(request)
+	if err != nil {
+		return fmt.Errorf("invalid %s request: %w", name, err)
+	}
+	limitQuantity, err := resource.ParseQuantity(limit)
+	if err != nil {
+		return fmt.Errorf("invalid %s limit: %w", name, err)
+	}
+	if requestQuantity.Cmp(limitQuantity) > 0 {
+		return fmt.Errorf("%s request %s exceeds limit %s", name, request, limit)
+	}
+	if maximum == "" {
+		return nil
+	}
+	maxQuantity, err := resource.ParseQuantity(maximum)
+	if err != nil {
+		return err
+	}
+	if limitQuantity.Cmp(maxQuantity) > 0 {
+		return fmt.Errorf("%s limit %s exceeds maximum %s", name, limit, maximum)
+	}
+	return nil
+}
+
+// storeResources records the resources of the cloud operators of a pipeline as step of s.
+func (f *FlowEngine) storeResources(s *saga, pipelineId string, resources map[string]lib.OperatorResources) error {
+	previous, err := f.resources.Get(pipelineId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if maps.Equal(previous, resources) {
+		return nil
+	}
+	data := map[string]string{}
+	if previous != nil {
+		encoded, err := json.Marshal(previous)
+		if err != nil {
+			return err
+		}
+		data["resources"] = string(encoded)
+	}
+	return s.step(stepStoreResources, data, func() error {
+		return f.resources.Put(pipelineId, resources)
+	}, func() error {
+		return f.restoreResources(pipelineId, data)
+	})
+}
+
+// restoreResources puts back the resources recorded in the data of a store resources step.
+func (f *FlowEngine) restoreResources(pipelineId string, data map[string]string) error {
+	if data["resources"] == "" {
+		return f.resources.Delete(pipelineId)
+	}
+	var resources map[string]lib.OperatorResources
+	if err := json.Unmarshal([]byte(data["resources"]), &resources); err != nil {
+		return err
+	}
+	return f.resources.Put(pipelineId, resources)
+}
+
+// getResources returns the stored resources of the cloud operators of a pipeline,
+// nil for pipelines started before resources were configurable.
+func (f *FlowEngine) getResources(id string) map[string]lib.OperatorResources {
+	resources, err := f.resources.Get(id)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			util.Logger.Error("cannot get resources", "pipeline", id, "error", err)
+		}
+		return nil
+	}
+	return resources
+}
+
+// redeployRequired reports whether the cloud operators of pipeline are deployed in another mode
+// or a cloud operator gets other resources than it is running with.
+func redeployRequired(pipeline pipe.Pipeline, running, pipeConfig lib.PipelineConfig) bool {
+	if running.DeploymentMode != pipeConfig.DeploymentMode {
+		return true
+	}
+	_, cloudOperators := seperateOperators(pipeline)
+	for _, operator := range cloudOperators {
+		if running.OperatorResources(operator.Id) != pipeConfig.OperatorResources(operator.Id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_resolveResources(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	pipeline := pipe.Pipeline{Operators: []pipe.Operator{
+		{Id: "default", DeploymentType: "cloud"},
+		{Id: "cost", DeploymentType: "cloud", Cost: 2},
+		{Id: "node", DeploymentType: "cloud", Cost: 3},
+		{Id: "local", DeploymentType: "local"},
+	}}
+
+	resources, err := f.resolveResources(lib.PipelineRequest{
+		Nodes: []lib.PipelineNode{{NodeId: "node", Resources: &lib.OperatorResources{Profile: lib.ResourceProfileSmall, MemoryLimit: "1Gi"}}},
+	}, pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 3 {
+		t.Errorf("expected resources of cloud operators only, got %v", resources)
+	}
+	if resources["default"] != lib.ResourceProfiles[lib.ResourceProfileSmall] {
+		t.Errorf("expected default profile, got %v", resources["default"])
+	}
+	if resources["cost"] != lib.ResourceProfiles[lib.ResourceProfileMedium] {
+		t.Errorf("expected profile from cost, got %v", resources["cost"])
+	}
+	expected := lib.ResourceProfiles[lib.ResourceProfileSmall]
+	expected.MemoryLimit = "1Gi"
+	if resources["node"] != expected {
+		t.Errorf("expected node resources, got %v", resources["node"])
+	}
+
+	resources, err = f.resolveResources(lib.PipelineRequest{Resources: &lib.OperatorResources{Profile: lib.ResourceProfileLarge}}, pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resources["cost"] != lib.ResourceProfiles[lib.ResourceProfileLarge] {
+		t.Errorf("expected pipeline resources to take precedence over cost, got %v", resources["cost"])
+	}
+
+	invalid := []lib.OperatorResources{
+		{Profile: "huge"},
+		{CpuRequest: "lots"},
+		{CpuRequest: "600m"},
+		{MemoryLimit: "8Gi"},
+	}
+	for _, requested := range invalid {
+		_, err = f.resolveResources(lib.PipelineRequest{Resources: &requested}, pipeline)
+		var inputErr *lib.InputError
+		if !errors.As(err, &inputErr) {
+			t.Errorf("expected input error for %v, got %v", requested, err)
+		}
+	}
+}
+
+func TestFlowEngine_resolveScaling(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	f.resourcesCfg.MaxReplicas = 5
+	pipeline := pipe.Pipeline{Operators: []pipe.Operator{
+		{Id: "stateless", DeploymentType: "cloud"},
+		{Id: "persist", DeploymentType: "cloud", PersistData: true},
+	}}
+
+	resources, err := f.resolveResources(lib.PipelineRequest{
+		Resources: &lib.OperatorResources{Scaling: lib.OperatorScaling{Replicas: 2, MaxReplicas: 4, TargetConsumerLag: 100}},
+	}, pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resources["stateless"].Scaling != (lib.OperatorScaling{Replicas: 2, MinReplicas: 2, MaxReplicas: 4, TargetConsumerLag: 100}) {
+		t.Errorf("expected min replicas to default to replicas, got %v", resources["stateless"].Scaling)
+	}
+	if resources["persist"].Scaling != (lib.OperatorScaling{}) {
+		t.Errorf("expected operator persisting data not to be scaled, got %v", resources["persist"].Scaling)
+	}
+
+	invalid := []lib.OperatorScaling{
+		{Replicas: -1},
+		{Replicas: 6},
+		{MinReplicas: 2},
+		{MaxReplicas: 3},
+		{MinReplicas: 4, MaxReplicas: 3, TargetCpuUtilization: 80},
+		{MaxReplicas: 6, TargetCpuUtilization: 80},
+	}
+	for _, scaling := range invalid {
+		_, err = f.resolveResources(lib.PipelineRequest{Resources: &lib.OperatorResources{Scaling: scaling}}, pipeline)
+		var inputErr *lib.InputError
+		if !errors.As(err, &inputErr) {
+			t.Errorf("expected input error for %v, got %v", scaling, err)
+		}
+	}
+}
+
+func TestFlowEngine_storeResources(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	small := map[string]lib.OperatorResources{"op": lib.ResourceProfiles[lib.ResourceProfileSmall]}
+	large := map[string]lib.OperatorResources{"op": lib.ResourceProfiles[lib.ResourceProfileLarge]}
+	s := f.newSaga(lib.OperationTypeStart, "pid", "user")
+	if err := f.storeResources(s, "pid", small); err != nil {
+		t.Fatal(err)
+	}
+	s.complete()
+
+	s = f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+	if err := f.storeResources(s, "pid", large); err != nil {
+		t.Fatal(err)
+	}
+	if f.createPipelineConfig(pipe.Pipeline{Id: "pid"}).OperatorResources("op") != large["op"] {
+		t.Error("expected stored resources in pipeline config")
+	}
+	_ = s.fail(errInterrupted)
+	if f.getResources("pid")["op"] != small["op"] {
+		t.Errorf("expected resources to be restored by compensation, got %v", f.getResources("pid"))
+	}
+
+	f.forgetPipelineState("pid")
+	if f.getResources("pid") != nil {
+		t.Error("expected resources to be removed")
+	}
+}
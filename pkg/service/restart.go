@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// RestartPipeline replaces the running operators of a pipeline without changing it. Cloud operators are restarted
+// by the driver, local operators are stopped and started again. Application IDs and forwarding are kept,
+// so that consumers continue at their offsets.
+func (f *FlowEngine) RestartPipeline(id, userId, token string) error {
+	s := f.newSaga(lib.OperationTypeRestart, id, userId)
+	util.Logger.Debug("engine - restart pipeline: " + id)
+	f.locks.lock(id)
+	defer f.locks.unlock(id)
+	pipeline, err := f.pipelineService.GetPipeline(id, userId, token)
+	if err != nil {
+		return s.fail(err)
+	}
+	if f.isPaused(id) {
+		return s.fail(lib.NewInputError(errors.New("cannot restart paused pipeline")))
+	}
+
+	localOperators, cloudOperators := seperateOperators(pipeline)
+	if len(cloudOperators) > 0 {
+		err = s.step(stepRestartCloudOperators, nil, func() error {
+			return f.driver.RestartOperators(id, cloudOperators)
+		}, nil)
+		if err != nil {
+			return s.fail(err)
+		}
+	}
+	pipeConfig := f.createPipelineConfig(pipeline)
+	pipeConfig.UserId = userId
+	for _, operator := range localOperators {
+		if err = restartLocalOperator(s, pipeline, operator, pipeConfig); err != nil {
+			return s.fail(err)
+		}
+	}
+	s.complete()
+	util.Logger.Debug("restarted pipeline: " + id)
+	return nil
+}
+
+func restartLocalOperator(s *saga, pipeline pipe.Pipeline, operator pipe.Operator, pipeConfig lib.PipelineConfig) error {
+	return s.step(stepRestartLocalOperator, map[string]string{"operatorId": operator.Id}, func() error {
+		if err := stopFogOperator(pipeline.Id, operator, pipeline.UserId); err != nil {
+			return err
+		}
+		return startFogOperator(operator, pipeConfig, pipeline.UserId)
+	}, nil)
+}
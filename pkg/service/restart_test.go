@@ -0,0 +1,63 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_RestartPipeline(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", Operators: []pipe.Operator{{Id: "op", DeploymentType: "cloud"}}}}}
+	driver := newFakeDriver()
+	f := newTestEngine(driver, pipelines)
+	if err := f.RestartPipeline("pid", "user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(driver.restartedPipelines) != 1 || driver.restartedPipelines[0] != "pid" {
+		t.Errorf("unexpected restarted pipelines %v", driver.restartedPipelines)
+	}
+
+	_ = f.paused.Put("pid", lib.PausedPipeline{PipelineId: "pid"})
+	if err := f.RestartPipeline("pid", "user", ""); !errors.As(err, new(*lib.InputError)) {
+		t.Errorf("expected input error for paused pipeline, got %v", err)
+	}
+	if len(driver.restartedPipelines) != 1 {
+		t.Error("expected paused pipeline not to be restarted")
+	}
+}
+
+func TestFlowEngine_RestartOperator(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", Operators: []pipe.Operator{{Id: "op", DeploymentType: "cloud"}}}}}
+	driver := newFakeDriver()
+	f := newTestEngine(driver, pipelines)
+	if err := f.RestartOperator("pid", "op", "user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(driver.restartedOperators) != 1 || driver.restartedOperators[0] != "op" {
+		t.Errorf("unexpected restarted operators %v", driver.restartedOperators)
+	}
+	if err := f.RestartOperator("pid", "unknown", "user", ""); !errors.As(err, new(*lib.NotFoundError)) {
+		t.Errorf("expected not found error for unknown operator, got %v", err)
+	}
+}
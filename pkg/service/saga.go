@@ -0,0 +1,299 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"github.com/google/uuid"
+)
+
+const maxOperations = 1000
+
+const (
+	stepRegisterPipeline      = "register pipeline"
+	stepCreateCloudOperators  = "create cloud operators"
+	stepEnableCloudToFog      = "enable cloud2fog forwarding"
+	stepStartLocalOperator    = "start local operator"
+	stepEnableFogToCloud      = "enable fog2cloud forwarding"
+	stepCreateVersion         = "create cloud operators version"
+	stepAwaitVersion          = "await healthy version"
+	stepStopOldOperators      = "stop old operators"
+	stepUpdateRegistry        = "update pipeline registry"
+	stepStopOperators         = "stop operators"
+	stepDeleteFromRegistry    = "delete pipeline from registry"
+	stepMarkPaused            = "mark pipeline paused"
+	stepPauseCloudOperators   = "pause cloud operators"
+	stepStopForwarding        = "stop forwarding"
+	stepResumeCloudOperators  = "resume cloud operators"
+	stepUnmarkPaused          = "unmark pipeline paused"
+	stepStoreSchedule         = "store schedule"
+	stepStoreResources        = "store resources"
+	stepStoreDeploymentMode   = "store deployment mode"
+	stepRestartCloudOperators = "restart cloud operators"
+	stepRestartLocalOperator  = "restart local operator"
+	stepRestartCloudOperator  = "restart cloud operator"
+)
+
+// saga executes the side effects of a pipeline operation step by step.
+// Every completed step may register a compensating action, on failure these are executed in reverse order.
+type saga struct {
+	store         *operationStore
+	operation     lib.Operation
+	pipeline      *pipe.Pipeline
+	compensations []func() error
+}
+
+func (f *FlowEngine) newSaga(operationType, pipelineId, userId string) *saga {
+	now := time.Now().UTC()
+	s := &saga{
+		store: f.operations,
+		operation: lib.Operation{
+			Id:         uuid.NewString(),
+			Type:       operationType,
+			PipelineId: pipelineId,
+			UserId:     userId,
+			State:      lib.OperationStatePending,
+			Steps:      []lib.OperationStep{},
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		},
+	}
+	s.save()
+	return s
+}
+
+func (s *saga) setPipelineId(id string) {
+	s.operation.PipelineId = id
+	s.save()
+}
+
+// setPipeline records the pipeline the operation is about to write to the registry,
+// so that an interrupted operation can be finished.
+func (s *saga) setPipeline(pipeline pipe.Pipeline) {
+	s.pipeline = &pipeline
+	s.save()
+}
+
+// advance records the progress of the operation.
+func (s *saga) advance(state string) {
+	s.operation.State = state
+	s.save()
+}
+
+// step runs action and, if it succeeds, registers compensate to undo it. compensate may be nil.
+// data is recorded with the step, action may add to it.
+func (s *saga) step(name string, data map[string]string, action func() error, compensate func() error) error {
+	idx := len(s.operation.Steps)
+	s.operation.Steps = append(s.operation.Steps, lib.OperationStep{Name: name, State: lib.StepStateRunning, Data: data})
+	s.compensations = append(s.compensations, nil)
+	s.save()
+	if err := action(); err != nil {
+		s.operation.Steps[idx].State = lib.StepStateFailed
+		s.operation.Steps[idx].Error = err.Error()
+		s.save()
+		return err
+	}
+	s.operation.Steps[idx].State = lib.StepStateDone
+	s.compensations[idx] = compensate
+	s.save()
+	return nil
+}
+
+// fail compensates all completed or interrupted steps in reverse order and returns err.
+func (s *saga) fail(err error) error {
+	util.Logger.Warn("operation failed, compensating", "operation", s.operation.Id, "type", s.operation.Type, "pipeline", s.operation.PipelineId, "error", err)
+	s.operation.Error = err.Error()
+	state := lib.OperationStateCompensated
+	for i := len(s.operation.Steps) - 1; i >= 0; i-- {
+		stepState := s.operation.Steps[i].State
+		if (stepState != lib.StepStateDone && stepState != lib.StepStateInterrupted) || s.compensations[i] == nil {
+			continue
+		}
+		if cErr := s.compensations[i](); cErr != nil {
+			util.Logger.Error("cannot compensate step", "operation", s.operation.Id, "step", s.operation.Steps[i].Name, "error", cErr)
+			s.operation.Steps[i].State = lib.StepStateCompensationFailed
+			s.operation.Steps[i].Error = cErr.Error()
+			state = lib.OperationStateFailed
+		} else {
+			s.operation.Steps[i].State = lib.StepStateCompensated
+		}
+		s.save()
+	}
+	s.operation.State = state
+	s.save()
+	return err
+}
+
+func (s *saga) complete() {
+	s.operation.State = lib.OperationStateCompleted
+	s.save()
+}
+
+func (s *saga) save() {
+	s.operation.UpdatedAt = time.Now().UTC()
+	s.store.save(s.operation, s.pipeline)
+}
+
+func isFinished(operation lib.Operation) bool {
+	switch operation.State {
+	case lib.OperationStateCompleted, lib.OperationStateCompensated, lib.OperationStateFailed:
+		return true
+	}
+	return false
+}
+
+// journalEntry is the persisted form of an operation.
+type journalEntry struct {
+	Operation lib.Operation  `json:"operation"`
+	Pipeline  *pipe.Pipeline `json:"pipeline,omitempty"`
+}
+
+// operationStore keeps the most recent operations in memory and, if a journal is set, on disk.
+type operationStore struct {
+	mu         sync.Mutex
+	operations map[string]journalEntry
+	order      []string
+	journal    *store.FileStore[journalEntry]
+}
+
+func newOperationStore(journal *store.FileStore[journalEntry]) *operationStore {
+	return &operationStore{operations: make(map[string]journalEntry), journal: journal}
+}
+
+// load reads all journaled operations, oldest first.
+func (o *operationStore) load() error {
+	if o.journal == nil {
+		return nil
+	}
+	entries, err := o.journal.List()
+	if err != nil {
+		return err
+	}
+	sorted := slices.SortedFunc(maps.Values(entries), func(a, b journalEntry) int {
+		return a.Operation.CreatedAt.Compare(b.Operation.CreatedAt)
+	})
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range sorted {
+		o.add(entry)
+	}
+	return nil
+}
+
+func (o *operationStore) save(operation lib.Operation, pipeline *pipe.Pipeline) {
+	operation.Steps = slices.Clone(operation.Steps)
+	for i := range operation.Steps {
+		operation.Steps[i].Data = maps.Clone(operation.Steps[i].Data)
+	}
+	entry := journalEntry{Operation: operation}
+	if !isFinished(operation) {
+		entry.Pipeline = pipeline
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.add(entry)
+	if o.journal != nil {
+		if err := o.journal.Put(operation.Id, entry); err != nil {
+			util.Logger.Error("cannot journal operation", "operation", operation.Id, "error", err)
+		}
+	}
+}
+
+// add must be called with mu held.
+func (o *operationStore) add(entry journalEntry) {
+	id := entry.Operation.Id
+	if _, ok := o.operations[id]; !ok {
+		o.order = append(o.order, id)
+		if len(o.order) > maxOperations {
+			o.evict()
+		}
+	}
+	o.operations[id] = entry
+}
+
+// evict drops the oldest finished operation, must be called with mu held.
+func (o *operationStore) evict() {
+	idx := slices.IndexFunc(o.order, func(id string) bool { return isFinished(o.operations[id].Operation) })
+	if idx == -1 {
+		return
+	}
+	id := o.order[idx]
+	delete(o.operations, id)
+	o.order = slices.Delete(o.order, idx, idx+1)
+	if o.journal != nil {
+		if err := o.journal.Delete(id); err != nil {
+			util.Logger.Error("cannot remove operation from journal", "operation", id, "error", err)
+		}
+	}
+}
+
+func (o *operationStore) get(id string) (operation lib.Operation, ok bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entry, ok := o.operations[id]
+	return entry.Operation, ok
+}
+
+// list returns the operations, newest first, optionally only those of a single pipeline.
+func (o *operationStore) list(pipelineId string) (operations []lib.Operation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	operations = []lib.Operation{}
+	for i := len(o.order) - 1; i >= 0; i-- {
+		operation := o.operations[o.order[i]].Operation
+		if pipelineId != "" && operation.PipelineId != pipelineId {
+			continue
+		}
+		operations = append(operations, operation)
+	}
+	return
+}
+
+// unfinished returns the journal entries of operations which neither completed nor were compensated, oldest first.
+func (o *operationStore) unfinished() (entries []journalEntry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, id := range o.order {
+		if entry := o.operations[id]; !isFinished(entry.Operation) {
+			entries = append(entries, entry)
+		}
+	}
+	return
+}
+
+// GetOperations returns the recorded pipeline operations, newest first, optionally filtered by pipeline.
+func (f *FlowEngine) GetOperations(pipelineId string) []lib.Operation {
+	return f.operations.list(pipelineId)
+}
+
+// GetOperation returns a single recorded pipeline operation.
+func (f *FlowEngine) GetOperation(id string) (operation lib.Operation, err error) {
+	operation, ok := f.operations.get(id)
+	if !ok {
+		err = lib.NewNotFoundError(errors.New("operation not found"))
+	}
+	return
+}
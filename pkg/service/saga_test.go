@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+)
+
+func TestSaga_fail(t *testing.T) {
+	f := newTestEngine(nil, nil)
+	s := f.newSaga(lib.OperationTypeStart, "pid", "user")
+
+	var compensated []string
+	compensate := func(name string, err error) func() error {
+		return func() error {
+			compensated = append(compensated, name)
+			return err
+		}
+	}
+	_ = s.step("a", nil, func() error { return nil }, compensate("a", nil))
+	_ = s.step("b", nil, func() error { return nil }, nil)
+	_ = s.step("c", nil, func() error { return nil }, compensate("c", errors.New("still there")))
+	stepErr := errors.New("boom")
+	if err := s.step("d", nil, func() error { return stepErr }, compensate("d", nil)); !errors.Is(err, stepErr) {
+		t.Fatalf("expected step error, got %v", err)
+	}
+	if err := s.fail(stepErr); !errors.Is(err, stepErr) {
+		t.Fatalf("expected original error, got %v", err)
+	}
+
+	if !slices.Equal(compensated, []string{"c", "a"}) {
+		t.Errorf("unexpected compensation order %v", compensated)
+	}
+	operation, err := f.GetOperation(s.operation.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.State != lib.OperationStateFailed || operation.Error != "boom" {
+		t.Errorf("unexpected operation state %s, error %s", operation.State, operation.Error)
+	}
+	expected := []string{lib.StepStateCompensated, lib.StepStateDone, lib.StepStateCompensationFailed, lib.StepStateFailed}
+	for i, step := range operation.Steps {
+		if step.State != expected[i] {
+			t.Errorf("step %s: expected state %s, got %s", step.Name, expected[i], step.State)
+		}
+	}
+	if operations := f.GetOperations("other"); len(operations) != 0 {
+		t.Errorf("expected no operations for other pipeline, got %d", len(operations))
+	}
+}
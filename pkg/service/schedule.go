@@ -0,0 +1,234 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/cron"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+)
+
+const scheduleInterval = time.Minute
+
+// scheduledPipeline is the persisted schedule of a pipeline. Only activations after CheckedAt pause or resume
+// the pipeline, so that a pipeline paused or resumed manually stays that way until the next activation.
+type scheduledPipeline struct {
+	PipelineId string       `json:"pipelineId"`
+	UserId     string       `json:"userId"`
+	Schedule   lib.Schedule `json:"schedule"`
+	CheckedAt  time.Time    `json:"checkedAt"`
+}
+
+// ValidateSchedule returns an error if the cron expressions or the timezone of schedule are invalid.
+// A nil schedule is valid.
+func ValidateSchedule(schedule *lib.Schedule) error {
+	if schedule == nil {
+		return nil
+	}
+	_, _, _, err := parseSchedule(*schedule)
+	return err
+}
+
+func parseSchedule(schedule lib.Schedule) (pause, resume *cron.Schedule, location *time.Location, err error) {
+	if pause, err = cron.Parse(schedule.Pause); err != nil {
+		err = fmt.Errorf("invalid pause schedule: %w", err)
+		return
+	}
+	if resume, err = cron.Parse(schedule.Resume); err != nil {
+		err = fmt.Errorf("invalid resume schedule: %w", err)
+		return
+	}
+	location = time.UTC
+	if schedule.Timezone != "" {
+		if location, err = time.LoadLocation(schedule.Timezone); err != nil {
+			err = fmt.Errorf("invalid schedule timezone: %w", err)
+		}
+	}
+	return
+}
+
+// due returns whether the pipeline has to be paused or resumed because of an activation after CheckedAt.
+func (p scheduledPipeline) due(now time.Time) (pause bool, due bool, err error) {
+	pauseSchedule, resumeSchedule, location, err := parseSchedule(p.Schedule)
+	if err != nil {
+		return
+	}
+	now = now.In(location)
+	lastPause, pauseErr := pauseSchedule.Prev(now)
+	lastResume, resumeErr := resumeSchedule.Prev(now)
+	if pauseErr != nil && resumeErr != nil {
+		return
+	}
+	pause = resumeErr != nil || lastPause.After(lastResume)
+	last := lastResume
+	if pause {
+		last = lastPause
+	}
+	return pause, last.After(p.CheckedAt), nil
+}
+
+// storeSchedule records the schedule of a pipeline as step of s, a nil schedule removes it.
+// An unchanged schedule keeps its state, so that updating a pipeline does not apply it again.
+func (f *FlowEngine) storeSchedule(s *saga, pipelineId, userId string, schedule *lib.Schedule) error {
+	previous, err := f.schedules.Get(pipelineId)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	exists := err == nil
+	if schedule == nil && !exists {
+		return nil
+	}
+	data := map[string]string{}
+	if exists {
+		data["pause"] = previous.Schedule.Pause
+		data["resume"] = previous.Schedule.Resume
+		data["timezone"] = previous.Schedule.Timezone
+		data["checkedAt"] = previous.CheckedAt.Format(time.RFC3339)
+	}
+	return s.step(stepStoreSchedule, data, func() error {
+		if schedule == nil {
+			return f.schedules.Delete(pipelineId)
+		}
+		scheduled := scheduledPipeline{PipelineId: pipelineId, UserId: userId, Schedule: *schedule}
+		if exists && previous.Schedule == *schedule {
+			scheduled.CheckedAt = previous.CheckedAt
+		}
+		return f.schedules.Put(pipelineId, scheduled)
+	}, func() error {
+		return f.restoreSchedule(pipelineId, userId, data)
+	})
+}
+
+// restoreSchedule puts back the schedule recorded in the data of a store schedule step.
+func (f *FlowEngine) restoreSchedule(pipelineId, userId string, data map[string]string) error {
+	if data["pause"] == "" {
+		return f.schedules.Delete(pipelineId)
+	}
+	checkedAt, _ := time.Parse(time.RFC3339, data["checkedAt"])
+	return f.schedules.Put(pipelineId, scheduledPipeline{
+		PipelineId: pipelineId,
+		UserId:     userId,
+		Schedule:   lib.Schedule{Pause: data["pause"], Resume: data["resume"], Timezone: data["timezone"]},
+		CheckedAt:  checkedAt,
+	})
+}
+
+func (f *FlowEngine) getSchedule(id string) *lib.Schedule {
+	scheduled, err := f.schedules.Get(id)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			util.Logger.Error("cannot get schedule", "pipeline", id, "error", err)
+		}
+		return nil
+	}
+	return &scheduled.Schedule
+}
+
+// runScheduler pauses and resumes scheduled pipelines every minute until ctx is cancelled.
+func (f *FlowEngine) runScheduler(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			util.Logger.Info("stopping pipeline scheduler")
+			return
+		case now := <-ticker.C:
+			f.applySchedules(now)
+		}
+	}
+}
+
+// applySchedules pauses or resumes the scheduled pipelines with an activation since they were last checked.
+// Pipelines with an operation in progress and failed activations are retried with the next run.
+func (f *FlowEngine) applySchedules(now time.Time) {
+	schedules, err := f.schedules.List()
+	if err != nil {
+		util.Logger.Error("cannot list schedules", "error", err)
+		return
+	}
+	for id := range schedules {
+		if !f.locks.tryLock(id) {
+			continue
+		}
+		f.checkSchedule(id, now)
+		f.locks.unlock(id)
+	}
+}
+
+// checkSchedule applies the schedule of a pipeline if it is due, the caller has to hold the lock of the pipeline.
+// The schedule is read again, as it may have been changed or removed since it was listed.
+func (f *FlowEngine) checkSchedule(id string, now time.Time) {
+	scheduled, err := f.schedules.Get(id)
+	if errors.Is(err, store.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		util.Logger.Error("cannot get schedule", "pipeline", id, "error", err)
+		return
+	}
+	pause, due, err := scheduled.due(now)
+	if err != nil {
+		util.Logger.Error("invalid schedule", "pipeline", id, "error", err)
+		return
+	}
+	if !due {
+		return
+	}
+	util.Logger.Info("applying schedule", "pipeline", id, "pause", pause)
+	err = f.applySchedule(scheduled, pause)
+	var notFoundErr *lib.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		util.Logger.Warn("removing schedule of unknown pipeline", "pipeline", id)
+		f.forgetPipelineState(id)
+		return
+	}
+	if err != nil {
+		util.Logger.Error("cannot apply schedule", "pipeline", id, "pause", pause, "error", err)
+		return
+	}
+	scheduled.CheckedAt = now
+	if err = f.schedules.Put(id, scheduled); err != nil {
+		util.Logger.Error("cannot store schedule", "pipeline", id, "error", err)
+	}
+}
+
+func (f *FlowEngine) applySchedule(scheduled scheduledPipeline, pause bool) error {
+	id := scheduled.PipelineId
+	if f.isPaused(id) == pause {
+		return nil
+	}
+	operationType := lib.OperationTypeResume
+	if pause {
+		operationType = lib.OperationTypePause
+	}
+	s := f.newSaga(operationType, id, scheduled.UserId)
+	pipeline, err := f.getPipelineAdmin(id)
+	if err != nil {
+		return s.fail(err)
+	}
+	if pause {
+		return f.pausePipeline(s, pipeline, scheduled.UserId, "")
+	}
+	return f.resumePipeline(s, pipeline, scheduled.UserId, "")
+}
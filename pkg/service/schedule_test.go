@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// staleScheduleStore lists the schedules as they were before a change.
+type staleScheduleStore struct {
+	store.Store[scheduledPipeline]
+	listed map[string]scheduledPipeline
+}
+
+func (s *staleScheduleStore) List() (map[string]scheduledPipeline, error) {
+	return s.listed, nil
+}
+
+func TestFlowEngine_applySchedules(t *testing.T) {
+	util.InitStructLogger("error")
+	driver := newFakeDriver()
+	driver.status["pid"] = lib.PipelineStatus{Running: true}
+	f := newTestEngine(driver, &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid", Operators: []pipe.Operator{{Id: "op", DeploymentType: "cloud"}}}}})
+	s := f.newSaga(lib.OperationTypeStart, "pid", "user")
+	if err := f.storeSchedule(s, "pid", "user", &lib.Schedule{Pause: "0 18 * * *", Resume: "0 8 * * 1-5", Timezone: "Europe/Berlin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		now    time.Time
+		manual func()
+		paused bool
+	}{
+		// outside of the window when the schedule was stored
+		{now: time.Date(2026, 3, 27, 19, 0, 0, 0, time.UTC), paused: true},
+		{now: time.Date(2026, 3, 27, 19, 1, 0, 0, time.UTC), paused: true},
+		// manual changes are kept until the next activation
+		{now: time.Date(2026, 3, 28, 10, 0, 0, 0, time.UTC), manual: func() { _ = f.ResumePipeline("pid", "user", "") }, paused: false},
+		{now: time.Date(2026, 3, 28, 17, 0, 0, 0, time.UTC), paused: true},
+		{now: time.Date(2026, 3, 30, 6, 0, 0, 0, time.UTC), paused: false},
+	}
+	for i, step := range steps {
+		if step.manual != nil {
+			step.manual()
+		}
+		f.applySchedules(step.now)
+		if f.isPaused("pid") != step.paused || driver.status["pid"].Running == step.paused {
+			t.Errorf("step %d: expected paused %v", i, step.paused)
+		}
+	}
+	if scheduled, _ := f.schedules.Get("pid"); !scheduled.CheckedAt.Equal(steps[4].now) {
+		t.Errorf("expected schedule checked at last activation, got %v", scheduled.CheckedAt)
+	}
+
+	// a schedule removed after listing is not stored again
+	schedules := f.schedules
+	listed, _ := schedules.List()
+	_ = schedules.Delete("pid")
+	f.schedules = &staleScheduleStore{Store: schedules, listed: listed}
+	f.applySchedules(time.Date(2026, 3, 30, 18, 0, 0, 0, time.UTC))
+	if _, err := schedules.Get("pid"); err == nil {
+		t.Error("expected removed schedule not to be stored again")
+	}
+	_ = schedules.Put("pid", listed["pid"])
+	f.schedules = schedules
+
+	if schedule := f.getSchedule("pid"); schedule == nil || schedule.Pause != "0 18 * * *" {
+		t.Errorf("unexpected schedule %v", schedule)
+	}
+	s = f.newSaga(lib.OperationTypeUpdate, "pid", "user")
+	if err := f.storeSchedule(s, "pid", "user", nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.fail(errInterrupted)
+	if f.getSchedule("pid") == nil {
+		t.Error("expected schedule to be restored by compensation")
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	if err := ValidateSchedule(&lib.Schedule{Pause: "0 18 * * *", Resume: "0 8 * * *", Timezone: "Europe/Berlin"}); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateSchedule(&lib.Schedule{Pause: "0 18 * *", Resume: "0 8 * * *"}); err == nil {
+		t.Error("expected error for invalid cron expression")
+	}
+	if err := ValidateSchedule(&lib.Schedule{Pause: "0 18 * * *", Resume: "0 8 * * *", Timezone: "Nowhere/Town"}); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
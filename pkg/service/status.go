@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+// pausedStatus returns the status of a paused pipeline, it last transitioned when it was paused.
+func (f *FlowEngine) pausedStatus(id string) lib.PipelineStatus {
+	status := lib.PipelineStatus{Name: id, Paused: true, Schedule: f.getSchedule(id)}
+	if paused, err := f.paused.Get(id); err == nil {
+		status.LastTransitionTime = &paused.PausedAt
+	}
+	return status
+}
+
+// addPipelineState adds the schedule, the state of the local operators as far as it is known
+// and the forwarding between cloud and fog to status.
+func (f *FlowEngine) addPipelineState(status *lib.PipelineStatus, pipeline pipe.Pipeline) {
+	status.Schedule = f.getSchedule(pipeline.Id)
+	for _, operator := range pipeline.Operators {
+		if operator.DeploymentType == "local" {
+			fogStatus := fogOperatorStatus(pipeline.UserId, operator)
+			if fogStatus.State == lib.FogOperatorStateFailed && status.Message == "" {
+				status.Message = "local operator " + operator.Name + " failed: " + fogStatus.Error
+			}
+			status.FogOperators = append(status.FogOperators, fogStatus)
+		}
+		if !operator.DownstreamConfig.Enabled && !operator.UpstreamConfig.Enabled {
+			continue
+		}
+		forwarding := lib.ForwardingStatus{
+			OperatorId: operator.Id,
+			CloudToFog: operator.DownstreamConfig.Enabled,
+			FogToCloud: operator.UpstreamConfig.Enabled,
+		}
+		if forwarding.CloudToFog {
+			forwarding.CloudToFogInstance = operator.DownstreamConfig.InstanceID
+		}
+		if forwarding.FogToCloud {
+			forwarding.FogToCloudOutput = operator.OutputTopic
+		}
+		status.Forwarding = append(status.Forwarding, forwarding)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_GetPipelineStatus(t *testing.T) {
+	util.InitStructLogger("error")
+	pipeline := pipe.Pipeline{Id: "pid", Operators: []pipe.Operator{
+		{Id: "cloud", DeploymentType: "cloud"},
+		{Id: "local", Name: "local-op", DeploymentType: "local", OutputTopic: "local-output"},
+	}}
+	pipeline.Operators[0].DownstreamConfig.Enabled = true
+	pipeline.Operators[0].DownstreamConfig.InstanceID = "instance"
+	pipeline.Operators[1].UpstreamConfig.Enabled = true
+	driver := newFakeDriver()
+	driver.status["pid"] = lib.PipelineStatus{Running: true, ReadyReplicas: 1, DesiredReplicas: 1}
+	f := newTestEngine(driver, &fakePipelineService{pipelines: []pipe.Pipeline{pipeline}})
+
+	status, err := f.GetPipelineStatus("pid", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Running || status.ReadyReplicas != 1 {
+		t.Errorf("expected driver status to be kept, got %+v", status)
+	}
+	expectedFog := []lib.FogOperatorStatus{{OperatorId: "local", Name: "local-op", State: lib.FogOperatorStateUnknown}}
+	if len(status.FogOperators) != 1 || status.FogOperators[0] != expectedFog[0] {
+		t.Errorf("expected fog operators %+v, got %+v", expectedFog, status.FogOperators)
+	}
+	expectedForwarding := []lib.ForwardingStatus{
+		{OperatorId: "cloud", CloudToFog: true, CloudToFogInstance: "instance"},
+		{OperatorId: "local", FogToCloud: true, FogToCloudOutput: "local-output"},
+	}
+	if len(status.Forwarding) != 2 || status.Forwarding[0] != expectedForwarding[0] || status.Forwarding[1] != expectedForwarding[1] {
+		t.Errorf("expected forwarding %+v, got %+v", expectedForwarding, status.Forwarding)
+	}
+
+	pausedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_ = f.paused.Put("pid", lib.PausedPipeline{PipelineId: "pid", PausedAt: pausedAt})
+	status, err = f.GetPipelineStatus("pid", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Paused || status.Running || status.LastTransitionTime == nil || !status.LastTransitionTime.Equal(pausedAt) {
+		t.Errorf("expected paused status since %v, got %+v", pausedAt, status)
+	}
+}
+
+func TestFlowEngine_GetPipelinesStatus(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{
+		{Id: "pid", Operators: []pipe.Operator{{Id: "cloud", DeploymentType: "cloud"}}},
+		{Id: "fog", Operators: []pipe.Operator{{Id: "local", Name: "local-op", DeploymentType: "local"}}},
+	}}
+	driver := newFakeDriver()
+	driver.status["pid"] = lib.PipelineStatus{Running: true}
+	f := newTestEngine(driver, pipelines)
+
+	status, err := f.GetPipelineStatus("fog", "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.FogOperators) != 1 || status.FogOperators[0].OperatorId != "local" {
+		t.Errorf("expected status of local operator, got %+v", status)
+	}
+
+	statuses, err := f.GetPipelinesStatus(nil, "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].Name != "pid" || !statuses[0].Running || statuses[1].Name != "fog" || len(statuses[1].FogOperators) != 1 {
+		t.Errorf("unexpected status %+v", statuses)
+	}
+}
@@ -17,9 +17,27 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/config"
+	kafka2mqtt_api "github.com/SENERGY-Platform/analytics-flow-engine/pkg/kafka2mqtt-api"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/store"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+	"github.com/google/uuid"
 )
 
 func parseJsonFile(path string, _ interface{}) []byte {
@@ -35,3 +53,308 @@ func parseJsonFile(path string, _ interface{}) []byte {
 	byteValue, _ := ioutil.ReadAll(jsonFile)
 	return byteValue
 }
+
+// newTestEngine returns an engine with every store in memory and the default configuration,
+// tests set the services they need on top of driver and pipelines.
+func newTestEngine(driver Driver, pipelines PipelineApiService) *FlowEngine {
+	util.InitStructLogger("error")
+	return &FlowEngine{
+		driver:           driver,
+		pipelineService:  pipelines,
+		reconcile:        &reconcileState{},
+		gc:               &gcState{},
+		locks:            newPipelineLocks(),
+		operations:       newOperationStore(nil),
+		paused:           store.NewMemoryStore[lib.PausedPipeline](),
+		schedules:        store.NewMemoryStore[scheduledPipeline](),
+		resources:        store.NewMemoryStore[map[string]lib.OperatorResources](),
+		resourcesCfg:     config.ResourcesConfig{DefaultProfile: lib.ResourceProfileSmall, MaxCpu: "1000m", MaxMemory: "4000Mi", MaxReplicas: 10},
+		deploymentModes:  store.NewMemoryStore[string](),
+		deploymentMode:   lib.DeploymentModePipeline,
+		batchConcurrency: 1,
+		statusHub:        newStatusHub(),
+	}
+}
+
+// fakeDriver records the calls of the engine. The cloud operators of a pipeline run while it has a status,
+// which tests may set up front.
+type fakeDriver struct {
+	mu                 sync.Mutex
+	status             map[string]lib.PipelineStatus
+	created            []string
+	deleted            []string
+	planned            []string
+	restartedPipelines []string
+	restartedOperators []string
+	resources          []lib.PipelineResource
+	deletedResources   []string
+	// onGetPipelineResources is called while the resources are listed
+	onGetPipelineResources func()
+}
+
+func newFakeDriver() *fakeDriver {
+	return &fakeDriver{status: make(map[string]lib.PipelineStatus)}
+}
+
+func (d *fakeDriver) CreateOperators(pipelineId string, _ []pipe.Operator, _ lib.PipelineConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.created = append(d.created, pipelineId)
+	d.status[pipelineId] = lib.PipelineStatus{Running: true}
+	return nil
+}
+
+func (d *fakeDriver) PlanOperators(pipelineId string, operators []pipe.Operator, _ lib.PipelineConfig) ([]lib.PlannedResource, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, operator := range operators {
+		d.planned = append(d.planned, operator.Id)
+	}
+	return []lib.PlannedResource{{Kind: lib.ResourceKindDeployment, Name: "pipeline-" + pipelineId}}, nil
+}
+
+func (d *fakeDriver) DeleteOperator(pipelineId string, _ pipe.Operator) error {
+	return d.DeleteOperators(pipelineId, nil)
+}
+
+func (d *fakeDriver) DeleteOperators(pipelineId string, _ []pipe.Operator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleted = append(d.deleted, pipelineId)
+	delete(d.status, pipelineId)
+	return nil
+}
+
+func (d *fakeDriver) PauseOperators(pipelineId string, _ []pipe.Operator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status[pipelineId] = lib.PipelineStatus{}
+	return nil
+}
+
+func (d *fakeDriver) ResumeOperators(pipelineId string, _ []pipe.Operator, _ lib.PipelineConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status[pipelineId] = lib.PipelineStatus{Running: true}
+	return nil
+}
+
+func (d *fakeDriver) RestartOperators(pipelineId string, _ []pipe.Operator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.restartedPipelines = append(d.restartedPipelines, pipelineId)
+	return nil
+}
+
+func (d *fakeDriver) RestartOperator(_ string, operator pipe.Operator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.restartedOperators = append(d.restartedOperators, operator.Id)
+	return nil
+}
+
+func (d *fakeDriver) GetOperatorsStatus(string, []pipe.Operator) ([]lib.OperatorStatus, error) {
+	return []lib.OperatorStatus{}, nil
+}
+
+func (d *fakeDriver) GetPipelineStatus(pipelineId string) (lib.PipelineStatus, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status, ok := d.status[pipelineId]
+	if !ok {
+		return lib.PipelineStatus{}, lib.NewNotFoundError(errors.New("no deployment of pipeline " + pipelineId))
+	}
+	return status, nil
+}
+
+func (d *fakeDriver) GetPipelinesStatus() ([]lib.PipelineStatus, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var statuses []lib.PipelineStatus
+	for _, pipelineId := range slices.Sorted(maps.Keys(d.status)) {
+		status := d.status[pipelineId]
+		status.Name = "pipeline-" + pipelineId
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (d *fakeDriver) GetPipelineResources() ([]lib.PipelineResource, error) {
+	if d.onGetPipelineResources != nil {
+		d.onGetPipelineResources()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.resources), nil
+}
+
+func (d *fakeDriver) DeletePipelineResource(resource lib.PipelineResource) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deletedResources = append(d.deletedResources, resource.Name)
+	return nil
+}
+
+// fakeVersionedDriver runs the versions of a pipeline, which become healthy if healthy is set.
+type fakeVersionedDriver struct {
+	*fakeDriver
+	healthy         bool
+	versions        []string
+	deletedVersions []string
+}
+
+func (d *fakeVersionedDriver) CreateOperatorsVersion(_, version string, _ []pipe.Operator, _ lib.PipelineConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.versions = append(d.versions, version)
+	return nil
+}
+
+func (d *fakeVersionedDriver) DeleteOperatorsVersion(_, version string, _ []pipe.Operator) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deletedVersions = append(d.deletedVersions, version)
+	d.versions = slices.DeleteFunc(d.versions, func(v string) bool { return v == version })
+	return nil
+}
+
+func (d *fakeVersionedDriver) GetPipelineVersionStatus(string, string) (lib.PipelineStatus, error) {
+	return lib.PipelineStatus{Running: d.healthy}, nil
+}
+
+func (d *fakeVersionedDriver) GetPipelineVersions(string) ([]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.versions), nil
+}
+
+// fakeLogDriver returns "logs of <operatorId>" and records the options of the last request.
+type fakeLogDriver struct {
+	*fakeDriver
+	logOptions lib.LogOptions
+}
+
+func (d *fakeLogDriver) GetOperatorLogs(_ context.Context, _ string, operator pipe.Operator, options lib.LogOptions) (io.ReadCloser, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.logOptions = options
+	return io.NopCloser(strings.NewReader("logs of " + operator.Id)), nil
+}
+
+// fakeStateDriver keeps the state of pipelines in memory.
+type fakeStateDriver struct {
+	*fakeDriver
+	states map[string]*store.MemoryStore[json.RawMessage]
+}
+
+func (d *fakeStateDriver) StateStore(name string) store.Store[json.RawMessage] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.states == nil {
+		d.states = make(map[string]*store.MemoryStore[json.RawMessage])
+	}
+	if d.states[name] == nil {
+		d.states[name] = store.NewMemoryStore[json.RawMessage]()
+	}
+	return d.states[name]
+}
+
+// fakePipelineService is a registry of pipelines, pipelines of other users are forbidden if their user is set.
+// It records deletions and the most concurrent ones, which take deleteDelay. GetPipelines fails with getPipelinesErr if set.
+type fakePipelineService struct {
+	mu              sync.Mutex
+	pipelines       []pipe.Pipeline
+	getPipelinesErr error
+	deleted         []string
+	deleteDelay     time.Duration
+	deleting        atomic.Int32
+	maxDeleting     atomic.Int32
+}
+
+func (p *fakePipelineService) RegisterPipeline(pipeline *pipe.Pipeline, userId string, _ string) (uuid.UUID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id := uuid.New()
+	pipeline.Id = id.String()
+	pipeline.UserId = userId
+	p.pipelines = append(p.pipelines, *pipeline)
+	return id, nil
+}
+
+func (p *fakePipelineService) UpdatePipeline(pipeline *pipe.Pipeline, _ string, _ string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idx := slices.IndexFunc(p.pipelines, func(registered pipe.Pipeline) bool { return registered.Id == pipeline.Id })
+	if idx == -1 {
+		return lib.NewNotFoundError(errors.New("pipeline not found"))
+	}
+	p.pipelines[idx] = *pipeline
+	return nil
+}
+
+func (p *fakePipelineService) GetPipeline(id string, userId string, _ string) (pipe.Pipeline, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idx := slices.IndexFunc(p.pipelines, func(pipeline pipe.Pipeline) bool { return pipeline.Id == id })
+	if idx == -1 {
+		return pipe.Pipeline{}, lib.NewNotFoundError(errors.New("pipeline not found"))
+	}
+	if owner := p.pipelines[idx].UserId; owner != "" && owner != userId {
+		return pipe.Pipeline{}, lib.NewForbiddenError(errors.New("could not access pipeline " + id))
+	}
+	return p.pipelines[idx], nil
+}
+
+func (p *fakePipelineService) GetPipelines(string, string) ([]pipe.Pipeline, error) {
+	p.mu.Lock()
+	err := p.getPipelinesErr
+	p.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return p.GetPipelinesAdmin()
+}
+
+func (p *fakePipelineService) GetPipelinesAdmin() ([]pipe.Pipeline, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.pipelines), nil
+}
+
+func (p *fakePipelineService) DeletePipeline(id string, _ string, _ string) error {
+	deleting := p.deleting.Add(1)
+	defer p.deleting.Add(-1)
+	for {
+		maxDeleting := p.maxDeleting.Load()
+		if deleting <= maxDeleting || p.maxDeleting.CompareAndSwap(maxDeleting, deleting) {
+			break
+		}
+	}
+	time.Sleep(p.deleteDelay)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deleted = append(p.deleted, id)
+	return nil
+}
+
+// fakeKafka2MqttService starts instances with the id instance-<operatorId> and records the removed ones.
+type fakeKafka2MqttService struct {
+	mu      sync.Mutex
+	removed []string
+}
+
+func (k *fakeKafka2MqttService) StartOperatorInstance(_, operatorID string, _, _, _ string) (kafka2mqtt_api.Instance, error) {
+	return kafka2mqtt_api.Instance{Id: "instance-" + operatorID}, nil
+}
+
+func (k *fakeKafka2MqttService) GetOperatorInstanceConfig(_, operatorID string, pipelineID, _ string) kafka2mqtt_api.Instance {
+	password := "secret"
+	return kafka2mqtt_api.Instance{Filter: pipelineID + ":" + operatorID, CustomMqttPassword: &password}
+}
+
+func (k *fakeKafka2MqttService) RemoveInstance(id, _, _, _ string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.removed = append(k.removed, id)
+	return nil
+}
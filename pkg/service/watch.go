@@ -0,0 +1,272 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+const (
+	statusSubscriberBuffer = 64
+	// ownershipRefreshInterval limits how often the pipelines of a subscriber are fetched again
+	// when a status change of an unknown pipeline arrives.
+	ownershipRefreshInterval = 10 * time.Second
+)
+
+// statusHub distributes status changes of pipelines to the subscribers owning them.
+type statusHub struct {
+	mu           sync.Mutex
+	subscribers  map[*statusSubscriber]struct{}
+	driverStatus map[string]lib.PipelineStatus // last status reported by the driver, by pipeline ID
+	pending      map[string]lib.PipelineStatus // status reported by the driver but not yet published, by pipeline ID
+	notify       chan struct{}
+}
+
+func newStatusHub() *statusHub {
+	return &statusHub{
+		subscribers:  make(map[*statusSubscriber]struct{}),
+		driverStatus: make(map[string]lib.PipelineStatus),
+		pending:      make(map[string]lib.PipelineStatus),
+		notify:       make(chan struct{}, 1),
+	}
+}
+
+type statusSubscriber struct {
+	userId      string
+	token       string
+	mu          sync.Mutex
+	pipelines   map[string]pipe.Pipeline
+	refreshedAt time.Time
+	sent        map[string]lib.PipelineStatus
+	events      chan lib.PipelineStatus
+	closed      bool
+	err         error // why events was closed before the subscription ended
+}
+
+func (s *statusSubscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *statusSubscriber) closeWithError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.err = err
+	}
+	s.closeLocked()
+}
+
+func (s *statusSubscriber) closeErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// closeLocked must be called with mu held.
+func (s *statusSubscriber) closeLocked() {
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+// WatchPipelinesStatus returns the current status of the user's pipelines and a channel receiving their status
+// whenever it changes. The channel is closed once ctx is done, if the receiver does not keep up, or if the token is
+// no longer accepted by the pipeline registry, in which case closeErr returns an UnauthorizedError.
+func (f *FlowEngine) WatchPipelinesStatus(ctx context.Context, userId, token string) (initial []lib.PipelineStatus, events <-chan lib.PipelineStatus, closeErr func() error, err error) {
+	pipelines, err := f.pipelineService.GetPipelines(userId, token)
+	if err != nil {
+		return
+	}
+	initial, err = f.GetPipelinesStatus(nil, userId, token)
+	if err != nil {
+		return
+	}
+	sub := &statusSubscriber{
+		userId:      userId,
+		token:       token,
+		pipelines:   make(map[string]pipe.Pipeline, len(pipelines)),
+		refreshedAt: time.Now(),
+		sent:        make(map[string]lib.PipelineStatus, len(initial)),
+		events:      make(chan lib.PipelineStatus, statusSubscriberBuffer),
+	}
+	for _, pipeline := range pipelines {
+		sub.pipelines[pipeline.Id] = pipeline
+	}
+	for _, status := range initial {
+		sub.sent[status.Name] = status
+	}
+	f.statusHub.mu.Lock()
+	f.statusHub.subscribers[sub] = struct{}{}
+	f.statusHub.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		f.statusHub.mu.Lock()
+		delete(f.statusHub.subscribers, sub)
+		f.statusHub.mu.Unlock()
+		sub.close()
+	}()
+	return initial, sub.events, sub.closeErr, nil
+}
+
+// runStatusWatcher feeds the status changes reported by drivers implementing StatusWatcher to the subscribers.
+func (f *FlowEngine) runStatusWatcher(ctx context.Context) {
+	watcher, ok := f.driver.(StatusWatcher)
+	if !ok {
+		return
+	}
+	go f.publishDriverStatus(ctx)
+	if err := watcher.WatchPipelinesStatus(ctx, f.onDriverStatus); err != nil {
+		util.Logger.Error("cannot watch pipeline status, status changes of cloud operators are not streamed", "error", err)
+	}
+}
+
+// onDriverStatus only queues the status for publishDriverStatus, so that the driver is not blocked while it is published.
+// Only the latest status of a pipeline is kept.
+func (f *FlowEngine) onDriverStatus(pipelineId string, status lib.PipelineStatus) {
+	f.statusHub.mu.Lock()
+	f.statusHub.pending[pipelineId] = status
+	f.statusHub.mu.Unlock()
+	select {
+	case f.statusHub.notify <- struct{}{}:
+	default:
+	}
+}
+
+// publishDriverStatus publishes the status queued by onDriverStatus until ctx is done. The status of a pipeline
+// without replicas is not kept, as it equals the status of a pipeline unknown to the driver.
+func (f *FlowEngine) publishDriverStatus(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.statusHub.notify:
+		}
+		f.statusHub.mu.Lock()
+		pending := f.statusHub.pending
+		f.statusHub.pending = make(map[string]lib.PipelineStatus)
+		for pipelineId, status := range pending {
+			if !status.Running && status.DesiredReplicas == 0 {
+				delete(f.statusHub.driverStatus, pipelineId)
+				continue
+			}
+			f.statusHub.driverStatus[pipelineId] = status
+		}
+		f.statusHub.mu.Unlock()
+		for pipelineId, status := range pending {
+			f.publishStatus(pipelineId, status)
+		}
+	}
+}
+
+func (f *FlowEngine) onFogStatus(_, pipelineId string) {
+	f.statusHub.mu.Lock()
+	driverStatus := f.statusHub.driverStatus[pipelineId]
+	f.statusHub.mu.Unlock()
+	f.publishStatus(pipelineId, driverStatus)
+}
+
+// publishStatus sends the status of the pipeline to every subscriber owning it, if it changed since it was last sent.
+func (f *FlowEngine) publishStatus(pipelineId string, driverStatus lib.PipelineStatus) {
+	f.statusHub.mu.Lock()
+	subscribers := make([]*statusSubscriber, 0, len(f.statusHub.subscribers))
+	for sub := range f.statusHub.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	f.statusHub.mu.Unlock()
+
+	for _, sub := range subscribers {
+		pipeline, ok := f.subscribedPipeline(sub, pipelineId)
+		if !ok {
+			continue
+		}
+		var status lib.PipelineStatus
+		if f.isPaused(pipelineId) {
+			status = f.pausedStatus(pipelineId)
+		} else {
+			status = driverStatus
+			status.Name = pipelineId
+			f.addPipelineState(&status, pipeline)
+		}
+		sub.send(status)
+	}
+}
+
+// subscribedPipeline returns the pipeline if the subscriber owns it.
+// Pipelines created after subscribing are found by fetching the subscriber's pipelines again, a subscriber whose
+// token is no longer accepted is closed, so that the client subscribes again with a new one.
+func (f *FlowEngine) subscribedPipeline(sub *statusSubscriber, pipelineId string) (pipe.Pipeline, bool) {
+	sub.mu.Lock()
+	pipeline, ok := sub.pipelines[pipelineId]
+	refresh := !ok && !sub.closed && time.Since(sub.refreshedAt) >= ownershipRefreshInterval
+	if refresh {
+		sub.refreshedAt = time.Now()
+	}
+	sub.mu.Unlock()
+	if !refresh {
+		return pipeline, ok
+	}
+	// the registry is requested without holding mu, so that sending to the subscriber is not blocked meanwhile
+	pipelines, err := f.pipelineService.GetPipelines(sub.userId, sub.token)
+	if errors.As(err, new(*lib.UnauthorizedError)) {
+		util.Logger.Info("token of status subscriber not accepted, closing it", "user", sub.userId)
+		sub.closeWithError(err)
+		return pipe.Pipeline{}, false
+	}
+	if err != nil {
+		util.Logger.Warn("cannot refresh pipelines of status subscriber", "user", sub.userId, "error", err)
+		return pipe.Pipeline{}, false
+	}
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.pipelines = make(map[string]pipe.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		sub.pipelines[pipeline.Id] = pipeline
+	}
+	pipeline, ok = sub.pipelines[pipelineId]
+	return pipeline, ok
+}
+
+// send queues the status unless it equals the status last sent. A subscriber whose buffer is full is closed,
+// it has to subscribe again to receive the current status.
+func (s *statusSubscriber) send(status lib.PipelineStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if last, ok := s.sent[status.Name]; ok && reflect.DeepEqual(last, status) {
+		return
+	}
+	s.sent[status.Name] = status
+	select {
+	case s.events <- status:
+	default:
+		util.Logger.Warn("status subscriber does not keep up, closing it", "user", s.userId)
+		s.closeLocked()
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/analytics-flow-engine/lib"
+	"github.com/SENERGY-Platform/analytics-flow-engine/pkg/util"
+	pipe "github.com/SENERGY-Platform/analytics-pipeline/lib"
+)
+
+func TestFlowEngine_WatchPipelinesStatus(t *testing.T) {
+	util.InitStructLogger("error")
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid"}}}
+	driver := newFakeDriver()
+	driver.status["pid"] = lib.PipelineStatus{Running: true}
+	f := newTestEngine(driver, pipelines)
+	ctx, cancel := context.WithCancel(context.Background())
+	initial, events, closeErr, err := f.WatchPipelinesStatus(ctx, "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(initial) != 1 || initial[0].Name != "pid" || !initial[0].Running {
+		t.Fatalf("unexpected initial status %+v", initial)
+	}
+
+	go f.publishDriverStatus(ctx)
+	f.onDriverStatus("pid", lib.PipelineStatus{Running: true})
+	f.onDriverStatus("other", lib.PipelineStatus{Running: true})
+	f.onDriverStatus("pid", lib.PipelineStatus{Transitioning: true, DesiredReplicas: 1})
+	select {
+	case status := <-events:
+		if status.Name != "pid" || !status.Transitioning {
+			t.Errorf("expected changed status of pid, got %+v", status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected status change")
+	}
+	select {
+	case status := <-events:
+		t.Errorf("expected unchanged status and status of foreign pipeline not to be sent, got %+v", status)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the status of deleted deployments is sent, but not kept
+	f.onDriverStatus("pid", lib.PipelineStatus{})
+	if status := <-events; status.Name != "pid" || status.Running || status.Transitioning {
+		t.Errorf("expected stopped status, got %+v", status)
+	}
+	f.statusHub.mu.Lock()
+	_, kept := f.statusHub.driverStatus["pid"]
+	f.statusHub.mu.Unlock()
+	if kept {
+		t.Error("expected status of pipeline without replicas to be removed")
+	}
+
+	_ = f.paused.Put("pid", lib.PausedPipeline{PipelineId: "pid"})
+	f.onFogStatus("user", "pid")
+	if status := <-events; !status.Paused || status.Name != "pid" {
+		t.Errorf("expected paused status, got %+v", status)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("expected no further status")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected events to be closed")
+	}
+	if err = closeErr(); err != nil {
+		t.Errorf("expected no error after the subscription ended, got %v", err)
+	}
+}
+
+func TestFlowEngine_WatchPipelinesStatus_unauthorized(t *testing.T) {
+	pipelines := &fakePipelineService{pipelines: []pipe.Pipeline{{Id: "pid"}}}
+	f := newTestEngine(newFakeDriver(), pipelines)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, events, closeErr, err := f.WatchPipelinesStatus(ctx, "user", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token expired before a pipeline unknown to the subscriber changed
+	pipelines.getPipelinesErr = lib.NewUnauthorizedError(errors.New("token expired"))
+	f.statusHub.mu.Lock()
+	for sub := range f.statusHub.subscribers {
+		sub.refreshedAt = time.Time{}
+	}
+	f.statusHub.mu.Unlock()
+	f.publishStatus("new", lib.PipelineStatus{Running: true})
+
+	if _, ok := <-events; ok {
+		t.Error("expected events to be closed")
+	}
+	if err = closeErr(); !errors.As(err, new(*lib.UnauthorizedError)) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
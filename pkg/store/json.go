@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import "encoding/json"
+
+// JSONStore keeps values as JSON in a store of raw messages, such as the stores drivers provide in the cluster.
+type JSONStore[T any] struct {
+	raw Store[json.RawMessage]
+}
+
+func NewJSONStore[T any](raw Store[json.RawMessage]) *JSONStore[T] {
+	return &JSONStore[T]{raw: raw}
+}
+
+func (s *JSONStore[T]) Put(key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.raw.Put(key, data)
+}
+
+func (s *JSONStore[T]) Get(key string) (value T, err error) {
+	data, err := s.raw.Get(key)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &value)
+	return
+}
+
+func (s *JSONStore[T]) Delete(key string) error {
+	return s.raw.Delete(key)
+}
+
+// List returns all stored values by key.
+func (s *JSONStore[T]) List() (map[string]T, error) {
+	raw, err := s.raw.List()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]T, len(raw))
+	for key, data := range raw {
+		var value T
+		if err = json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"maps"
+	"path/filepath"
+	"sync"
+)
+
+// Store is implemented by FileStore, MemoryStore and JSONStore.
+type Store[T any] interface {
+	Put(key string, value T) error
+	Get(key string) (T, error)
+	Delete(key string) error
+	List() (map[string]T, error)
+}
+
+// MemoryStore keeps values in memory only, it is used if no data directory is configured.
+type MemoryStore[T any] struct {
+	mu     sync.Mutex
+	values map[string]T
+}
+
+func NewMemoryStore[T any]() *MemoryStore[T] {
+	return &MemoryStore[T]{values: make(map[string]T)}
+}
+
+func (s *MemoryStore[T]) Put(key string, value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *MemoryStore[T]) Get(key string) (value T, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	if !ok {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (s *MemoryStore[T]) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+// List returns all stored values by key.
+func (s *MemoryStore[T]) List() (map[string]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.values), nil
+}
+
+// Open returns a FileStore in the sub directory name of dir, or a MemoryStore if dir is empty.
+func Open[T any](dir, name string) (Store[T], error) {
+	if dir == "" {
+		return NewMemoryStore[T](), nil
+	}
+	return NewFileStore[T](filepath.Join(dir, name))
+}
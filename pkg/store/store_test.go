@@ -0,0 +1,52 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	s, err := NewFileStore[map[string]int](t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Put("a", map[string]int{"x": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Put("a", map[string]int{"x": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Put("../b", nil); err == nil {
+		t.Error("expected error for key outside of store")
+	}
+	value, err := s.Get("a")
+	if err != nil || value["x"] != 2 {
+		t.Errorf("unexpected value %v, error %v", value, err)
+	}
+	values, err := s.List()
+	if err != nil || len(values) != 1 {
+		t.Errorf("unexpected values %v, error %v", values, err)
+	}
+	if err = s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const mountInfoPath = "/proc/self/mountinfo"
+
+// CheckVolume creates dir and returns an error if it is not on a mounted volume, as the stored values would be lost with the container.
+// File systems in memory and the root file system of the container do not count as a volume, without /proc/self/mountinfo
+// the volume cannot be determined.
+func CheckVolume(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(mountInfoPath)
+	if err != nil {
+		return errors.New("store - cannot determine volume of " + dir + ": " + err.Error())
+	}
+	defer file.Close()
+	mountPoint, fsType, err := findMount(file, path)
+	if err != nil {
+		return err
+	}
+	if mountPoint == "/" || slices.Contains([]string{"tmpfs", "ramfs"}, fsType) {
+		return errors.New("store - " + dir + " is not backed by a volume, its state would be lost on restart")
+	}
+	return nil
+}
+
+// findMount returns the mount point and file system type of the mount in mountinfo which contains path.
+func findMount(mountInfo io.Reader, path string) (mountPoint string, fsType string, err error) {
+	scanner := bufio.NewScanner(mountInfo)
+	for scanner.Scan() {
+		// ID parentID major:minor root mountPoint options [optional fields...] - fsType source superOptions
+		fields := strings.Fields(scanner.Text())
+		separator := slices.Index(fields, "-")
+		if len(fields) < 5 || separator == -1 || separator+1 >= len(fields) {
+			continue
+		}
+		point := unescapeMountPoint(fields[4])
+		if point != "/" && path != point && !strings.HasPrefix(path, point+"/") {
+			continue
+		}
+		if len(point) >= len(mountPoint) {
+			mountPoint, fsType = point, fields[separator+1]
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if mountPoint == "" {
+		err = errors.New("store - no mount found for " + path)
+	}
+	return
+}
+
+// unescapeMountPoint replaces the octal escapes of space, tab, newline and backslash in mountinfo.
+func unescapeMountPoint(point string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(point)
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMountInfo = `22 1 0:21 / / rw,relatime - overlay overlay rw,lowerdir=/a,upperdir=/b
+23 22 0:22 / /root/data rw,relatime - ext4 /dev/sdb rw
+24 22 0:23 / /root/data\040tmp rw,relatime - tmpfs tmpfs rw
+25 22 0:24 / /root/database rw,relatime - ext4 /dev/sdc rw
+`
+
+func TestFindMount(t *testing.T) {
+	for path, expected := range map[string][2]string{
+		"/root/data":            {"/root/data", "ext4"},
+		"/root/data/operations": {"/root/data", "ext4"},
+		"/root/data tmp/x":      {"/root/data tmp", "tmpfs"},
+		"/root/datastore":       {"/", "overlay"},
+		"/tmp":                  {"/", "overlay"},
+	} {
+		mountPoint, fsType, err := findMount(strings.NewReader(testMountInfo), path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mountPoint != expected[0] || fsType != expected[1] {
+			t.Errorf("%s: expected %v, got %s %s", path, expected, mountPoint, fsType)
+		}
+	}
+}
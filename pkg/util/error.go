@@ -36,5 +36,11 @@ func GetStatusCode(err error) int {
 	if _, ok := errors.AsType[*lib.ForbiddenError](err); ok {
 		return http.StatusForbidden
 	}
+	if _, ok := errors.AsType[*lib.UnauthorizedError](err); ok {
+		return http.StatusUnauthorized
+	}
+	if _, ok := errors.AsType[*lib.UnavailableError](err); ok {
+		return http.StatusServiceUnavailable
+	}
 	return 0
 }